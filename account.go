@@ -1,11 +1,10 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strconv"
-	"time"
 
 	"github.com/jaztec/go-binance/model"
 )
@@ -14,13 +13,15 @@ const accountPath = "/api/v3/account"
 
 func init() {
 	requireSignature(accountPath)
+	setWeight(http.MethodGet, accountPath, 20)
 }
 
 func (a *api) Account() (ai model.AccountInfo, err error) {
-	q := NewParameters(1)
-	q.Set("timestamp", strconv.FormatInt(time.Now().Unix()*1000, 10))
+	return a.AccountContext(context.Background())
+}
 
-	body, err := a.Request(http.MethodGet, accountPath, q)
+func (a *api) AccountContext(ctx context.Context) (ai model.AccountInfo, err error) {
+	body, err := a.RequestContext(ctx, http.MethodGet, accountPath, nil)
 	if err != nil {
 		return ai, err
 	}
@@ -14,8 +14,14 @@ import (
 
 const userDataStreamPath = "/api/v3/userDataStream"
 
+func init() {
+	setWeight(http.MethodPost, userDataStreamPath, 2)
+	setWeight(http.MethodPut, userDataStreamPath, 2)
+	retrySafe(http.MethodPut, userDataStreamPath)
+}
+
 func (s *streamer) UserDataStream(ctx context.Context) (<-chan model.UserAccountUpdate, error) {
-	res, err := s.api.Request(http.MethodPost, userDataStreamPath, nil)
+	res, err := s.api.RequestContext(ctx, http.MethodPost, userDataStreamPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -79,5 +85,10 @@ func findModel(in []byte) (model.UserAccountUpdate, error) {
 		err := json.Unmarshal(in, &out)
 		return out, err
 	}
+	if cmp(in, model.ListStatusType) {
+		out := model.ListStatus{}
+		err := json.Unmarshal(in, &out)
+		return out, err
+	}
 	return nil, errors.New("no AccountUpdateType matched")
 }
@@ -1,8 +1,9 @@
 package binance
 
 import (
+	"context"
+	"net"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/jaztec/go-binance/model"
@@ -13,43 +14,39 @@ const (
 	BaseAPIURI = "https://api.binance.com"
 	// APIKeyHeaderName is the header name Binance API expects the API token to be
 	APIKeyHeaderName = "X-MBX-APIKEY"
+	// DefaultHTTPTimeout is the timeout applied to a single request when no HTTPClient is configured
+	DefaultHTTPTimeout = 30 * time.Second
 )
 
-type weightChecker struct {
-	allowed bool
-	weight  int
+// defaultTransport is shared by every API without a custom HTTPClient or Transport so
+// connections to Binance are pooled and reused between instances.
+var defaultTransport http.RoundTripper = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   10,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+	ResponseHeaderTimeout: 20 * time.Second,
 }
 
-func (wc *weightChecker) deactivate(seconds int) {
-	wc.allowed = false
-	go func(seconds int) {
-		tC := time.Tick(time.Second * time.Duration(seconds))
-		<-tC
-		wc.allowed = true
-	}(seconds)
-}
-
-func (wc *weightChecker) checkResponse(response *http.Response) error {
-	fn := func(response *http.Response) {
-		retry := response.Header.Get("Retry-After")
-		if retry == "" {
-			return
-		}
-		i, err := strconv.Atoi(retry)
-		if err != nil {
-			panic(err)
-		}
-		wc.deactivate(i)
+func newHTTPClient(cfg APIConfig) *http.Client {
+	if cfg.HTTPClient != nil {
+		return cfg.HTTPClient
 	}
-	if response.StatusCode == http.StatusTooManyRequests {
-		fn(response)
-		return TooMuchCalls
+	transport := defaultTransport
+	if cfg.Transport != nil {
+		transport = cfg.Transport
 	}
-	if response.StatusCode == http.StatusTeapot {
-		fn(response)
-		return Blocked
+	return &http.Client{
+		Transport: transport,
+		Timeout:   DefaultHTTPTimeout,
 	}
-	return nil
 }
 
 // APIConfig lets us setup the values required by the adapter
@@ -58,12 +55,39 @@ type APIConfig struct {
 	Key string
 	// Secret attached to the API Key
 	Secret string
+	// Signer signs the requests to signed endpoints, allowing RSA or Ed25519 keys to be used.
+	// Will default to a HMACSigner using Secret
+	Signer Signer
 	// BaseURI of the API. Will default to BaseAPIURI
 	BaseURI string
 	// BaseStreamURI for the websocket API. Will default to BaseStreamURI
 	BaseStreamURI string
 	// Logger allows setting a custom logger
 	Logger Logger
+	// HTTPClient used to perform the REST calls. When set, Transport is ignored. Will default
+	// to a client using a shared, pooled transport and DefaultHTTPTimeout
+	HTTPClient *http.Client
+	// Transport used by the default HTTP client, allows setting proxies, mTLS or instrumentation
+	// while keeping the default timeout
+	Transport http.RoundTripper
+	// WaitOnRateLimit makes calls block until the rate limit window resets when they would
+	// exceed the limits. By default these calls fail with LimitExceeded, this can be
+	// overridden per call with WithWaitOnRateLimit
+	WaitOnRateLimit bool
+	// RecvWindow in milliseconds applied to all signed requests, Binance uses 5000 when it is
+	// not set. It can be overridden per call with WithRecvWindow or OrderParams.RecvWindow
+	RecvWindow int64
+	// Retry configures retrying calls that failed because of transient problems. Retrying
+	// is disabled by default
+	Retry RetryPolicy
+	// ValidateOrders checks orders against the filters of the cached exchange information
+	// before sending them, violations are returned as FilterError. The symbol information
+	// is fetched on the first order when it was not retrieved yet
+	ValidateOrders bool
+	// ExchangeInfoTTL is how long cached symbol information is used before it is fetched
+	// again. When zero it is kept until ExchangeInfo is called or an order on the symbol is
+	// refused because of the symbol or its filters
+	ExchangeInfoTTL time.Duration
 }
 
 // API interface exposes all the available (implemented) endpoints to the Binance REST API. The Streamer can be
@@ -73,6 +97,12 @@ type API interface {
 	// and deactivate the API for the period defined by the API when in violation. The function
 	// will return the raw body of the result on success or an error on failure.
 	Request(method, path string, params Parameters) ([]byte, error)
+	// RequestContext behaves like Request but will abort the call when the provided
+	// context is canceled or its deadline expires. In that case ctx.Err() is returned.
+	RequestContext(ctx context.Context, method, path string, params Parameters) ([]byte, error)
+	// BannedUntil returns the time until which calls are refused because the API rate limits
+	// were violated. The zero time is returned when no ban has been issued.
+	BannedUntil() time.Time
 
 	// Stream returns a Streamer
 	Stream() Streamer
@@ -84,42 +114,181 @@ type APICaller interface {
 
 	// Account information
 	Account() (ai model.AccountInfo, err error)
+	// AccountContext is Account with a context
+	AccountContext(ctx context.Context) (ai model.AccountInfo, err error)
 	// AllOrders for a symbol from the user account
 	AllOrders(symbol string, startTime, endTime int64, limit int) ([]model.UserOrder, error)
+	// AllOrdersContext is AllOrders with a context
+	AllOrdersContext(ctx context.Context, symbol string, startTime, endTime int64, limit int) ([]model.UserOrder, error)
+	// QueryOrder returns the status of an order identified by either its order id or client order id
+	QueryOrder(symbol string, orderID int, origClientOrderID string) (model.UserOrder, error)
+	// QueryOrderContext is QueryOrder with a context
+	QueryOrderContext(ctx context.Context, symbol string, orderID int, origClientOrderID string) (model.UserOrder, error)
+	// OpenOrders of a symbol from the user account. An empty symbol returns the open orders of
+	// all symbols. WARNING, heavy penalty without a symbol
+	OpenOrders(symbol string) ([]model.UserOrder, error)
+	// OpenOrdersContext is OpenOrders with a context
+	OpenOrdersContext(ctx context.Context, symbol string) ([]model.UserOrder, error)
 	// AvgPrice of a symbol
 	AvgPrice(symbol string) (model.AvgPrice, error)
-	// Depth endpoint on Binance API
+	// AvgPriceContext is AvgPrice with a context
+	AvgPriceContext(ctx context.Context, symbol string) (model.AvgPrice, error)
+	// Depth endpoint on Binance API. The limit must be between 1 and 5000, the weight grows with it,
+	// zero uses the Binance default of 100
 	Depth(symbol string, limit int) (model.Orders, error)
+	// DepthContext is Depth with a context
+	DepthContext(ctx context.Context, symbol string, limit int) (model.Orders, error)
 	// ExchangeInfo as set by Binance
 	ExchangeInfo() (model.ExchangeInfo, error)
+	// ExchangeInfoContext is ExchangeInfo with a context
+	ExchangeInfoContext(ctx context.Context) (model.ExchangeInfo, error)
+	// ExchangeInfoWithParams returns the exchange information of a set of symbols or permissions
+	ExchangeInfoWithParams(params ExchangeInfoParams) (model.ExchangeInfo, error)
+	// ExchangeInfoWithParamsContext is ExchangeInfoWithParams with a context
+	ExchangeInfoWithParamsContext(ctx context.Context, params ExchangeInfoParams) (model.ExchangeInfo, error)
+	// Symbol returns the information of a symbol from the cache, fetching it when it is
+	// missing or expired
+	Symbol(name string) (model.SymbolInfo, error)
+	// SymbolContext is Symbol with a context
+	SymbolContext(ctx context.Context, name string) (model.SymbolInfo, error)
+	// Klines returns the candlesticks of a symbol, the times are in milliseconds and may be
+	// zero. The timeZone (e.g. "+08:00") defines the interval boundaries, it defaults to UTC
+	Klines(symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) ([]model.Kline, error)
+	// KlinesContext is Klines with a context
+	KlinesContext(ctx context.Context, symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) ([]model.Kline, error)
+	// UIKlines is Klines with the candlesticks modified for presentation
+	UIKlines(symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) ([]model.Kline, error)
+	// UIKlinesContext is UIKlines with a context
+	UIKlinesContext(ctx context.Context, symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) ([]model.Kline, error)
+	// KlineIterator walks the klines of a symbol between startTime and endTime in milliseconds
+	// page by page. startTime is required, a zero endTime walks up to the current kline
+	KlineIterator(ctx context.Context, symbol string, interval model.KlineInterval, startTime, endTime int64) *KlineIterator
+	// WalkKlines calls fn for every kline of a symbol between startTime and endTime in
+	// milliseconds, it stops at the first error returned by fn
+	WalkKlines(ctx context.Context, symbol string, interval model.KlineInterval, startTime, endTime int64, fn func(model.Kline) error) error
 	// Order to put into the Binance system
 	Order(symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error)
+	// OrderContext is Order with a context
+	OrderContext(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error)
+	// CancelOrder cancels an active order identified by either its order id or client order id
+	CancelOrder(symbol string, orderID int, origClientOrderID string) (model.CancelOrderResponse, error)
+	// CancelOrderContext is CancelOrder with a context
+	CancelOrderContext(ctx context.Context, symbol string, orderID int, origClientOrderID string) (model.CancelOrderResponse, error)
+	// CancelReplaceOrder cancels an existing order and places a new order on the same symbol
+	CancelReplaceOrder(symbol string, side model.OrderSide, orderType model.OrderType, cancel CancelReplaceParams, params OrderParams) (model.CancelReplaceResponse, error)
+	// CancelReplaceOrderContext is CancelReplaceOrder with a context
+	CancelReplaceOrderContext(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, cancel CancelReplaceParams, params OrderParams) (model.CancelReplaceResponse, error)
+	// CancelAllOpenOrders cancels all active orders on a symbol, orders that are part of an
+	// order list, like an OCO, are returned as the canceled list
+	CancelAllOpenOrders(symbol string) ([]model.CancelOrderResponse, []model.OrderList, error)
+	// CancelAllOpenOrdersContext is CancelAllOpenOrders with a context
+	CancelAllOpenOrdersContext(ctx context.Context, symbol string) ([]model.CancelOrderResponse, []model.OrderList, error)
+	// NewOCO places a one-cancels-the-other order list
+	NewOCO(symbol string, side model.OrderSide, params OCOParams) (model.OrderList, error)
+	// NewOCOContext is NewOCO with a context
+	NewOCOContext(ctx context.Context, symbol string, side model.OrderSide, params OCOParams) (model.OrderList, error)
+	// CancelOrderList cancels an entire order list identified by either its id or client order id
+	CancelOrderList(symbol string, orderListID int, listClientOrderID string) (model.OrderList, error)
+	// CancelOrderListContext is CancelOrderList with a context
+	CancelOrderListContext(ctx context.Context, symbol string, orderListID int, listClientOrderID string) (model.OrderList, error)
+	// QueryOrderList returns an order list identified by either its id or client order id
+	QueryOrderList(orderListID int, origClientOrderID string) (model.OrderList, error)
+	// QueryOrderListContext is QueryOrderList with a context
+	QueryOrderListContext(ctx context.Context, orderListID int, origClientOrderID string) (model.OrderList, error)
+	// AllOrderLists returns the order lists of the user account
+	AllOrderLists(fromID int, startTime, endTime int64, limit int) ([]model.OrderList, error)
+	// AllOrderListsContext is AllOrderLists with a context
+	AllOrderListsContext(ctx context.Context, fromID int, startTime, endTime int64, limit int) ([]model.OrderList, error)
+	// OpenOrderLists returns the open order lists of the user account
+	OpenOrderLists() ([]model.OrderList, error)
+	// OpenOrderListsContext is OpenOrderLists with a context
+	OpenOrderListsContext(ctx context.Context) ([]model.OrderList, error)
 	// OrderTest will validate an order but not put it into the system
 	OrderTest(symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error)
-	// Ticker24h will retrieve information about a symbol for a 24H period. WARNING, heavy penalty
-	Ticker24h(symbol string) ([]model.TickerStatistics, error)
-	// TickerPrice returns price information about a symbol
-	TickerPrice(symbol string) ([]model.Price, error)
+	// OrderTestContext is OrderTest with a context
+	OrderTestContext(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error)
+	// Ticker24h will retrieve information about symbols for a 24H period, all symbols are returned
+	// when none are provided. WARNING, heavy penalty for all or more than 20 symbols
+	Ticker24h(symbols ...string) ([]model.TickerStatistics, error)
+	// Ticker24hContext is Ticker24h with a context
+	Ticker24hContext(ctx context.Context, symbols ...string) ([]model.TickerStatistics, error)
+	// TickerPrice returns price information about symbols, all symbols are returned when none
+	// are provided
+	TickerPrice(symbols ...string) ([]model.Price, error)
+	// TickerPriceContext is TickerPrice with a context
+	TickerPriceContext(ctx context.Context, symbols ...string) ([]model.Price, error)
+	// BookTicker returns the best price and quantity on the order book of symbols, all symbols
+	// are returned when none are provided
+	BookTicker(symbols ...string) ([]model.BookTicker, error)
+	// BookTickerContext is BookTicker with a context
+	BookTickerContext(ctx context.Context, symbols ...string) ([]model.BookTicker, error)
+	// RollingTicker returns the price change statistics of up to 100 symbols over a window of
+	// 1-59m, 1-23h or 1-7d. The window defaults to 1d when empty. The weight is 4 per symbol
+	RollingTicker(windowSize string, symbols ...string) ([]model.TickerStatistics, error)
+	// RollingTickerContext is RollingTicker with a context
+	RollingTickerContext(ctx context.Context, windowSize string, symbols ...string) ([]model.TickerStatistics, error)
 	// MyTrades returns trades performed by a user in a time window
 	MyTrades(symbol string, startTime, endTime int64, limit int) (t []model.UserTrade, err error)
+	// MyTradesContext is MyTrades with a context
+	MyTradesContext(ctx context.Context, symbol string, startTime, endTime int64, limit int) (t []model.UserTrade, err error)
+	// Trades returns the most recent trades of a symbol
+	Trades(symbol string, limit int) ([]model.MarketTrade, error)
+	// TradesContext is Trades with a context
+	TradesContext(ctx context.Context, symbol string, limit int) ([]model.MarketTrade, error)
+	// HistoricalTrades returns the trades of a symbol starting at trade id fromID, or the most
+	// recent trades when fromID is zero. Requires an API key
+	HistoricalTrades(symbol string, fromID int64, limit int) ([]model.MarketTrade, error)
+	// HistoricalTradesContext is HistoricalTrades with a context
+	HistoricalTradesContext(ctx context.Context, symbol string, fromID int64, limit int) ([]model.MarketTrade, error)
+	// AggTrades returns the aggregate trades of a symbol starting at aggregate trade id fromID,
+	// or between startTime and endTime in milliseconds which must be less than an hour apart.
+	// The most recent aggregate trades are returned when all are zero
+	AggTrades(symbol string, fromID, startTime, endTime int64, limit int) ([]model.AggTrade, error)
+	// AggTradesContext is AggTrades with a context
+	AggTradesContext(ctx context.Context, symbol string, fromID, startTime, endTime int64, limit int) ([]model.AggTrade, error)
+
+	// Time returns the current time of the Binance servers
+	Time() (model.ServerTime, error)
+	// TimeContext is Time with a context
+	TimeContext(ctx context.Context) (model.ServerTime, error)
+	// SyncTime measures the offset between the local clock and the Binance servers once, the
+	// offset is applied to the timestamp of all signed requests
+	SyncTime(ctx context.Context) error
+	// StartTimeSync synchronises the clock offset now and keeps doing so every interval
+	// until the context is done
+	StartTimeSync(ctx context.Context, interval time.Duration) error
+	// TimeOffset returns how far the Binance servers are ahead of the local clock
+	TimeOffset() time.Duration
+	// Ping tests the connectivity with the REST API
+	Ping() error
+	// PingContext is Ping with a context
+	PingContext(ctx context.Context) error
+	// SystemStatus reports whether Binance is in maintenance
+	SystemStatus() (model.SystemStatus, error)
+	// SystemStatusContext is SystemStatus with a context
+	SystemStatusContext(ctx context.Context) (model.SystemStatus, error)
+	// HealthCheck reports the reachability, latency, clock offset, maintenance status and
+	// used weight of the API in one call
+	HealthCheck(ctx context.Context) (Health, error)
 
 	// StreamCaller returns a stream with readily implemented functions
 	StreamCaller() StreamCaller
 }
 
 type api struct {
+	// timeOffset in milliseconds, kept first to guarantee 64-bit alignment for atomic access
+	timeOffset   int64
 	cfg          APIConfig
+	httpClient   *http.Client
+	signer       Signer
 	checker      *weightChecker
 	logger       Logger
 	streamer     Streamer
-	exchangeInfo *model.ExchangeInfo
+	exchangeInfo *exchangeInfoCache
 }
 
-func (a *api) exchangeInfoValid() bool {
-	if a.exchangeInfo == nil {
-		return false
-	}
-	return true
+func (a *api) BannedUntil() time.Time {
+	return a.checker.banned()
 }
 
 func (a *api) Stream() Streamer {
@@ -136,6 +305,10 @@ func newAPI(cfg APIConfig) (*api, error) {
 	if cfg.Logger != nil {
 		logger = cfg.Logger
 	}
+	signer := cfg.Signer
+	if signer == nil {
+		signer = HMACSigner{Secret: cfg.Secret}
+	}
 	if cfg.BaseURI == "" {
 		cfg.BaseURI = BaseAPIURI
 	}
@@ -143,12 +316,12 @@ func newAPI(cfg APIConfig) (*api, error) {
 		cfg.BaseStreamURI = BaseStreamURI
 	}
 	a := &api{
-		cfg: cfg,
-		checker: &weightChecker{
-			allowed: true,
-			weight:  0,
-		},
-		logger: logger,
+		cfg:          cfg,
+		httpClient:   newHTTPClient(cfg),
+		signer:       signer,
+		checker:      newWeightChecker(),
+		logger:       logger,
+		exchangeInfo: newExchangeInfoCache(cfg.ExchangeInfoTTL),
 	}
 
 	a.streamer = newStreamer(a, logger)
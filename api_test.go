@@ -1,14 +1,21 @@
 package binance_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/jaztec/go-binance"
+	"github.com/jaztec/go-binance/model"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -40,6 +47,15 @@ func testServer(expectedPath string, expectedQueryParts map[string]struct{}, sta
 	}))
 }
 
+type countingTransport struct {
+	calls int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.calls++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
 func newAPI(uri string) binance.APICaller {
 	defer GinkgoRecover()
 
@@ -79,6 +95,172 @@ var _ = Describe("Api", func() {
 		})
 	})
 
+	Context("use a custom HTTP setup", func() {
+		It("should route requests through the configured transport", func() {
+			res := loadFixture("avg_price_data")
+			ts := testServer("/api/v3/avgPrice", map[string]struct{}{
+				"symbol": {},
+			}, http.StatusOK, res, nil)
+			defer ts.Close()
+
+			rt := &countingTransport{}
+			a, err := binance.NewAPICaller(binance.APIConfig{
+				BaseURI:   ts.URL,
+				Transport: rt,
+			})
+			Expect(err).To(BeNil())
+
+			_, err = a.AvgPrice("ETHBTC")
+			Expect(err).To(BeNil())
+			Expect(rt.calls).To(Equal(1))
+		})
+
+		It("should prefer the configured client over the transport", func() {
+			res := loadFixture("avg_price_data")
+			ts := testServer("/api/v3/avgPrice", map[string]struct{}{
+				"symbol": {},
+			}, http.StatusOK, res, nil)
+			defer ts.Close()
+
+			rt := &countingTransport{}
+			clientRt := &countingTransport{}
+			a, err := binance.NewAPICaller(binance.APIConfig{
+				BaseURI:    ts.URL,
+				Transport:  rt,
+				HTTPClient: &http.Client{Transport: clientRt},
+			})
+			Expect(err).To(BeNil())
+
+			_, err = a.AvgPrice("ETHBTC")
+			Expect(err).To(BeNil())
+			Expect(rt.calls).To(Equal(0))
+			Expect(clientRt.calls).To(Equal(1))
+		})
+	})
+
+	Context("synchronise with the server time", func() {
+		It("should correct the timestamp of signed requests", func() {
+			var timestamp int64
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				now := time.Now().UnixNano() / int64(time.Millisecond)
+				switch r.URL.Path {
+				case "/api/v3/time":
+					_, _ = fmt.Fprintf(w, `{"serverTime":%d}`, now+5000)
+				case "/api/v3/account":
+					timestamp, _ = strconv.ParseInt(r.URL.Query().Get("timestamp"), 10, 64)
+					timestamp -= now
+					_, _ = w.Write(loadFixture("account_data"))
+				}
+			}))
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			Expect(a.SyncTime(context.Background())).To(BeNil())
+			Expect(a.TimeOffset()).To(BeNumerically("~", 5*time.Second, 100*time.Millisecond))
+
+			_, err := a.Account()
+			Expect(err).To(BeNil())
+			Expect(timestamp).To(BeNumerically("~", 5000, 100))
+		})
+	})
+
+	Context("send a recvWindow with signed requests", func() {
+		var recvWindow string
+		var ts *httptest.Server
+
+		BeforeEach(func() {
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				recvWindow = r.URL.Query().Get("recvWindow")
+				_, _ = w.Write(loadFixture("account_data"))
+			}))
+		})
+
+		AfterEach(func() {
+			ts.Close()
+		})
+
+		It("should use the configured default", func() {
+			a, err := binance.NewAPICaller(binance.APIConfig{BaseURI: ts.URL, RecvWindow: 10000})
+			Expect(err).To(BeNil())
+
+			_, err = a.Account()
+			Expect(err).To(BeNil())
+			Expect(recvWindow).To(Equal("10000"))
+		})
+
+		It("should allow overriding the default per call", func() {
+			a, err := binance.NewAPICaller(binance.APIConfig{BaseURI: ts.URL, RecvWindow: 10000})
+			Expect(err).To(BeNil())
+
+			_, err = a.AccountContext(binance.WithRecvWindow(context.Background(), 20000))
+			Expect(err).To(BeNil())
+			Expect(recvWindow).To(Equal("20000"))
+		})
+
+		It("should not be sent when not configured", func() {
+			_, err := newAPI(ts.URL).Account()
+			Expect(err).To(BeNil())
+			Expect(recvWindow).To(BeEmpty())
+		})
+	})
+
+	Context("retry transient failures", func() {
+		var calls int32
+		var ts *httptest.Server
+
+		BeforeEach(func() {
+			calls = 0
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					_, _ = w.Write([]byte(`{"code":-1001,"msg":"Internal error; unable to process your request. Please try again."}`))
+					return
+				}
+				_, _ = w.Write(loadFixture("buy_order_ack_data"))
+			}))
+		})
+
+		AfterEach(func() {
+			ts.Close()
+		})
+
+		newRetryAPI := func(attempts int) binance.APICaller {
+			a, err := binance.NewAPICaller(binance.APIConfig{
+				Key:     apiKey,
+				Secret:  apiSecret,
+				BaseURI: ts.URL,
+				Retry: binance.RetryPolicy{
+					MaxAttempts:    attempts,
+					InitialBackoff: time.Millisecond,
+					MaxBackoff:     5 * time.Millisecond,
+				},
+			})
+			Expect(err).To(BeNil())
+			return a
+		}
+
+		It("should retry GET calls", func() {
+			a := newRetryAPI(3)
+			_, err := a.Request(http.MethodGet, "/api/v3/order", nil)
+			Expect(err).To(BeNil())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+		})
+
+		It("should give up after the maximum attempts", func() {
+			a := newRetryAPI(2)
+			_, err := a.Request(http.MethodGet, "/api/v3/order", nil)
+			Expect(err).ToNot(BeNil())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+		})
+
+		It("should never retry placing an order", func() {
+			a := newRetryAPI(3)
+			_, err := a.Order("DOGEUSDT", model.Buy, model.Market, binance.OrderParams{Quantity: model.MustParseDecimal("50")})
+			Expect(err).ToNot(BeNil())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+	})
+
 	Context("API weight results must be respected", func() {
 		Context("Should halt on warning", func() {
 			It("should respect API limits", func() {
@@ -98,6 +280,78 @@ var _ = Describe("Api", func() {
 			})
 		})
 
+		Context("Should expose the ban", func() {
+			It("should report until when calls are refused", func() {
+				ts := testServer("/api/v3/avgPrice", map[string]struct{}{
+					"symbol": {},
+				}, http.StatusTeapot, nil, map[string]string{"Retry-After": "30"})
+				defer ts.Close()
+
+				a := newAPI(ts.URL)
+				Expect(a.BannedUntil().IsZero()).To(BeTrue())
+
+				_, err := a.AvgPrice("ETHBTC")
+				Expect(err).ToNot(BeNil())
+				Expect(a.BannedUntil()).To(BeTemporally("~", time.Now().Add(30*time.Second), time.Second))
+			})
+
+			It("should return an error on an invalid Retry-After header", func() {
+				ts := testServer("/api/v3/avgPrice", map[string]struct{}{
+					"symbol": {},
+				}, http.StatusTooManyRequests, nil, map[string]string{"Retry-After": "soon"})
+				defer ts.Close()
+
+				a := newAPI(ts.URL)
+				_, err := a.AvgPrice("ETHBTC")
+				Expect(err).ToNot(BeNil())
+				Expect(errors.Is(err, binance.TooMuchCalls)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("soon"))
+				Expect(a.BannedUntil().IsZero()).To(BeTrue())
+			})
+
+			It("should be safe for concurrent use", func() {
+				ts := testServer("/api/v3/avgPrice", map[string]struct{}{
+					"symbol": {},
+				}, http.StatusTooManyRequests, nil, map[string]string{"Retry-After": "1"})
+				defer ts.Close()
+
+				a := newAPI(ts.URL)
+				wg := sync.WaitGroup{}
+				for i := 0; i < 20; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						_, _ = a.AvgPrice("ETHBTC")
+						_ = a.BannedUntil()
+					}()
+				}
+				wg.Wait()
+
+				_, err := a.AvgPrice("ETHBTC")
+				Expect(err).To(Equal(binance.AtTimeout))
+			})
+		})
+
+		Context("Should track the used weight", func() {
+			It("should reject calls that would exceed the weight limit", func() {
+				calls := 0
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls++
+					w.Header().Set("X-MBX-USED-WEIGHT-1M", "1199")
+					_, _ = w.Write(loadFixture("avg_price_data"))
+				}))
+				defer ts.Close()
+
+				a := newAPI(ts.URL)
+				_, err := a.AvgPrice("ETHBTC")
+				Expect(err).To(BeNil())
+
+				_, err = a.AvgPrice("ETHBTC")
+				Expect(err).To(Equal(binance.LimitExceeded))
+				Expect(calls).To(Equal(1))
+			})
+		})
+
 		Context("Should halt on block", func() {
 			It("should respect API limits", func() {
 				ts := testServer("/api/v3/avgPrice", map[string]struct{}{
@@ -117,6 +371,43 @@ var _ = Describe("Api", func() {
 		})
 	})
 
+	Context("requests should respect the provided context", func() {
+		It("should return the context error when the deadline expires", func() {
+			done := make(chan struct{})
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-done:
+				}
+			}))
+			defer ts.Close()
+			defer close(done)
+
+			a := newAPI(ts.URL)
+			ctx, cancelFn := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancelFn()
+
+			_, err := a.AvgPriceContext(ctx, "ETHBTC")
+			Expect(err).To(Equal(context.DeadlineExceeded))
+		})
+
+		It("should not call the API with a canceled context", func() {
+			var called int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.StoreInt32(&called, 1)
+			}))
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			ctx, cancelFn := context.WithCancel(context.Background())
+			cancelFn()
+
+			_, err := a.AccountContext(ctx)
+			Expect(err).To(Equal(context.Canceled))
+			Expect(atomic.LoadInt32(&called)).To(BeZero())
+		})
+	})
+
 	Context("call API endpoints", func() {
 
 		Context("should call account data", func() {
@@ -140,8 +431,8 @@ var _ = Describe("Api", func() {
 				Expect(ai.UpdateTime).To(Equal(1619000000000))
 				Expect(ai.Balances).To(HaveLen(1))
 				Expect(ai.Balances[0].Asset).To(Equal("BTC"))
-				Expect(ai.Balances[0].Free).To(Equal("10.00000000"))
-				Expect(ai.Balances[0].Locked).To(Equal("5.00000000"))
+				Expect(ai.Balances[0].Free.String()).To(Equal("10.00000000"))
+				Expect(ai.Balances[0].Locked.String()).To(Equal("5.00000000"))
 			})
 
 			It("should work on error", func() {
@@ -184,7 +475,7 @@ var _ = Describe("Api", func() {
 				Expect(err).To(BeNil(), "calling AvgPrice should not return error")
 
 				Expect(av.Mins).To(Equal(5))
-				Expect(av.Price).To(Equal("0.06656334"))
+				Expect(av.Price.String()).To(Equal("0.06656334"))
 			})
 
 			It("should detect missing symbol", func() {
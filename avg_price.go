@@ -1,6 +1,7 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,14 +11,22 @@ import (
 
 const avgPricePath = "/api/v3/avgPrice"
 
+func init() {
+	setWeight(http.MethodGet, avgPricePath, 2)
+}
+
 func (a *api) AvgPrice(symbol string) (ap model.AvgPrice, err error) {
+	return a.AvgPriceContext(context.Background(), symbol)
+}
+
+func (a *api) AvgPriceContext(ctx context.Context, symbol string) (ap model.AvgPrice, err error) {
 	if symbol == "" {
 		return ap, NoSymbolProvided
 	}
 	q := NewParameters(1)
 	q.Set("symbol", symbol)
 
-	body, err := a.Request(http.MethodGet, avgPricePath, q)
+	body, err := a.RequestContext(ctx, http.MethodGet, avgPricePath, q)
 	if err != nil {
 		return ap, err
 	}
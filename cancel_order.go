@@ -0,0 +1,108 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+func init() {
+	setWeight(http.MethodDelete, orderPath, 1)
+	setWeight(http.MethodDelete, openOrdersPath, 1)
+}
+
+func (a *api) CancelOrder(symbol string, orderID int, origClientOrderID string) (model.CancelOrderResponse, error) {
+	return a.CancelOrderContext(context.Background(), symbol, orderID, origClientOrderID)
+}
+
+func (a *api) CancelOrderContext(ctx context.Context, symbol string, orderID int, origClientOrderID string) (co model.CancelOrderResponse, err error) {
+	if symbol == "" {
+		return co, NoSymbolProvided
+	}
+	q, err := orderIdentifier(symbol, orderID, origClientOrderID)
+	if err != nil {
+		return co, err
+	}
+
+	body, err := a.RequestContext(ctx, http.MethodDelete, orderPath, q)
+	if err != nil {
+		return co, err
+	}
+
+	err = json.Unmarshal(body, &co)
+	if err != nil {
+		return co, fmt.Errorf("encountered error while unmarshaling '%s' into model.CancelOrderResponse", body)
+	}
+
+	return co, nil
+}
+
+func (a *api) CancelAllOpenOrders(symbol string) ([]model.CancelOrderResponse, []model.OrderList, error) {
+	return a.CancelAllOpenOrdersContext(context.Background(), symbol)
+}
+
+func (a *api) CancelAllOpenOrdersContext(ctx context.Context, symbol string) (co []model.CancelOrderResponse, ol []model.OrderList, err error) {
+	if symbol == "" {
+		return co, ol, NoSymbolProvided
+	}
+	q := NewParameters(1)
+	q.Set("symbol", symbol)
+
+	body, err := a.RequestContext(ctx, http.MethodDelete, openOrdersPath, q)
+	if err != nil {
+		return co, ol, err
+	}
+
+	var entries []json.RawMessage
+	err = json.Unmarshal(body, &entries)
+	if err != nil {
+		return co, ol, fmt.Errorf("encountered error while unmarshaling '%s' into []json.RawMessage", body)
+	}
+
+	// the response mixes canceled orders with canceled order lists, the latter are
+	// recognized by their contingencyType
+	for _, e := range entries {
+		var probe struct {
+			ContingencyType string `json:"contingencyType"`
+		}
+		if err = json.Unmarshal(e, &probe); err != nil {
+			return co, ol, fmt.Errorf("encountered error while unmarshaling '%s' into model.CancelOrderResponse", e)
+		}
+		if probe.ContingencyType != "" {
+			var l model.OrderList
+			if err = json.Unmarshal(e, &l); err != nil {
+				return co, ol, fmt.Errorf("encountered error while unmarshaling '%s' into model.OrderList", e)
+			}
+			ol = append(ol, l)
+			continue
+		}
+		var o model.CancelOrderResponse
+		if err = json.Unmarshal(e, &o); err != nil {
+			return co, ol, fmt.Errorf("encountered error while unmarshaling '%s' into model.CancelOrderResponse", e)
+		}
+		co = append(co, o)
+	}
+
+	return co, ol, nil
+}
+
+// orderIdentifier returns parameters identifying a single order by either its
+// order id or the client order id
+func orderIdentifier(symbol string, orderID int, origClientOrderID string) (Parameters, error) {
+	if orderID == 0 && origClientOrderID == "" {
+		return nil, NoOrderIDProvided
+	}
+	q := NewParameters(3)
+	q.Set("symbol", symbol)
+	if orderID != 0 {
+		q.Set("orderId", strconv.Itoa(orderID))
+	}
+	if origClientOrderID != "" {
+		q.Set("origClientOrderId", origClientOrderID)
+	}
+	return q, nil
+}
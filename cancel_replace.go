@@ -0,0 +1,144 @@
+package binance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+const cancelReplacePath = "/api/v3/order/cancelReplace"
+
+func init() {
+	requireSignature(cancelReplacePath)
+	countsAsOrder(http.MethodPost, 1, cancelReplacePath)
+	setWeight(http.MethodPost, cancelReplacePath, 1)
+}
+
+// CancelReplaceParams identify the order to cancel in a cancel-replace. Either
+// CancelOrderID or CancelOrigClientOrderID is required.
+type CancelReplaceParams struct {
+	// Mode of the cancel-replace, will default to model.StopOnFailure
+	Mode                    model.CancelReplaceMode
+	CancelOrderID           int
+	CancelOrigClientOrderID string
+	CancelNewClientOrderID  string
+}
+
+// cancelReplaceResponse is the raw format of the response, the cancel and new order
+// responses hold either the result or an error.
+type cancelReplaceResponse struct {
+	CancelResult     model.CancelReplaceResult `json:"cancelResult"`
+	NewOrderResult   model.CancelReplaceResult `json:"newOrderResult"`
+	CancelResponse   json.RawMessage           `json:"cancelResponse"`
+	NewOrderResponse json.RawMessage           `json:"newOrderResponse"`
+}
+
+func (a *api) CancelReplaceOrder(symbol string, side model.OrderSide, orderType model.OrderType, cancel CancelReplaceParams, params OrderParams) (model.CancelReplaceResponse, error) {
+	return a.CancelReplaceOrderContext(context.Background(), symbol, side, orderType, cancel, params)
+}
+
+func (a *api) CancelReplaceOrderContext(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, cancel CancelReplaceParams, params OrderParams) (cr model.CancelReplaceResponse, err error) {
+	if symbol == "" {
+		return cr, NoSymbolProvided
+	}
+	if cancel.CancelOrderID == 0 && cancel.CancelOrigClientOrderID == "" {
+		return cr, NoOrderIDProvided
+	}
+	params, err = a.roundOrderParams(ctx, symbol, side, params)
+	if err != nil {
+		return cr, err
+	}
+	if err := checkOrderParams(orderType, params); err != nil {
+		return cr, err
+	}
+	if err := a.validateOrder(ctx, symbol, side, orderType, params); err != nil {
+		return cr, err
+	}
+	if cancel.Mode == "" {
+		cancel.Mode = model.StopOnFailure
+	}
+
+	p := NewParameters(15)
+	p.Set("symbol", symbol)
+	p.Set("side", string(side))
+	p.Set("type", string(orderType))
+	p.Set("cancelReplaceMode", string(cancel.Mode))
+	if cancel.CancelOrderID != 0 {
+		p.Set("cancelOrderId", strconv.Itoa(cancel.CancelOrderID))
+	}
+	if cancel.CancelOrigClientOrderID != "" {
+		p.Set("cancelOrigClientOrderId", cancel.CancelOrigClientOrderID)
+	}
+	if cancel.CancelNewClientOrderID != "" {
+		p.Set("cancelNewClientOrderId", cancel.CancelNewClientOrderID)
+	}
+
+	addOrderParams(p, params)
+
+	body, err := a.RequestContext(ctx, http.MethodPost, cancelReplacePath, p)
+	if err != nil {
+		a.refreshOnOrderError(symbol, err)
+		// a failed step is reported as an error holding the outcome of both steps
+		var apiErr APIError
+		if errors.As(err, &apiErr) && apiErr.err != nil && len(apiErr.err.Data) > 0 {
+			cr, decodeErr := decodeCancelReplace(apiErr.err.Data, params.NewOrderRespType)
+			if decodeErr != nil {
+				return cr, err
+			}
+			return cr, CancelReplaceError{APIError: apiErr, Response: cr}
+		}
+		return cr, err
+	}
+
+	return decodeCancelReplace(body, params.NewOrderRespType)
+}
+
+func decodeCancelReplace(body []byte, respType model.OrderResponseType) (cr model.CancelReplaceResponse, err error) {
+	var raw cancelReplaceResponse
+	err = json.Unmarshal(body, &raw)
+	if err != nil {
+		return cr, fmt.Errorf("encountered error while unmarshaling '%s' into model.CancelReplaceResponse", body)
+	}
+	cr.CancelResult = raw.CancelResult
+	cr.NewOrderResult = raw.NewOrderResult
+
+	if isPresent(raw.CancelResponse) {
+		if raw.CancelResult == model.Success {
+			cr.CancelResponse = &model.CancelOrderResponse{}
+			err = json.Unmarshal(raw.CancelResponse, cr.CancelResponse)
+		} else {
+			cr.CancelError = &model.Error{}
+			err = json.Unmarshal(raw.CancelResponse, cr.CancelError)
+		}
+		if err != nil {
+			return cr, err
+		}
+	}
+
+	if isPresent(raw.NewOrderResponse) {
+		if raw.NewOrderResult == model.Success {
+			i := orderResponse(respType)
+			if err = json.Unmarshal(raw.NewOrderResponse, i); err != nil {
+				return cr, err
+			}
+			cr.NewOrderResponse = i.(model.OrderResponse)
+		} else {
+			cr.NewOrderError = &model.Error{}
+			if err = json.Unmarshal(raw.NewOrderResponse, cr.NewOrderError); err != nil {
+				return cr, err
+			}
+		}
+	}
+
+	return cr, nil
+}
+
+func isPresent(raw json.RawMessage) bool {
+	return len(raw) > 0 && !bytes.Equal(raw, []byte("null"))
+}
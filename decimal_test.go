@@ -0,0 +1,80 @@
+package binance_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+var _ = Describe("Decimal", func() {
+	It("should parse and format without losing precision", func() {
+		for _, s := range []string{"0", "0.00000001", "-12.50000000", "92233720368547758070.12345678"} {
+			Expect(model.MustParseDecimal(s).String()).To(Equal(s))
+		}
+		Expect(model.MustParseDecimal("1e-8").String()).To(Equal("0.00000001"))
+		Expect(model.MustParseDecimal(".5").String()).To(Equal("0.5"))
+		Expect(model.NewDecimal(15, 3).String()).To(Equal("0.015"))
+		Expect(model.NewDecimalFromFloat(0.1).String()).To(Equal("0.1"))
+		Expect(model.Decimal{}.String()).To(Equal("0"))
+	})
+
+	It("should refuse invalid input", func() {
+		for _, s := range []string{"", "-", ".", "1.2.3", "abc", "--1", "1e"} {
+			_, err := model.ParseDecimal(s)
+			Expect(err).ToNot(BeNil(), s)
+		}
+	})
+
+	It("should bound the exponent", func() {
+		Expect(model.MustParseDecimal("1e64").Cmp(model.MustParseDecimal("1e63"))).To(Equal(1))
+		Expect(model.MustParseDecimal("1e-64").IsZero()).To(BeFalse())
+		for _, s := range []string{"1e65", "1e-65", "1e999999999", "1e-2147483648"} {
+			_, err := model.ParseDecimal(s)
+			Expect(err).ToNot(BeNil(), s)
+		}
+	})
+
+	It("should do exact arithmetic", func() {
+		a := model.MustParseDecimal("0.1")
+		b := model.MustParseDecimal("0.2")
+		Expect(a.Add(b).Equal(model.MustParseDecimal("0.3"))).To(BeTrue())
+		Expect(a.Sub(b).String()).To(Equal("-0.1"))
+		Expect(a.Mul(b).String()).To(Equal("0.02"))
+		Expect(model.MustParseDecimal("1").Div(model.MustParseDecimal("3"), 8).String()).To(Equal("0.33333333"))
+		Expect(model.MustParseDecimal("-1.239").Truncate(2).String()).To(Equal("-1.23"))
+		Expect(a.Cmp(b)).To(Equal(-1))
+		Expect(model.MustParseDecimal("1.000").Equal(model.MustParseDecimal("1"))).To(BeTrue())
+		Expect(model.MustParseDecimal("0.000").IsZero()).To(BeTrue())
+	})
+
+	It("should round to a step", func() {
+		step := model.MustParseDecimal("0.05")
+		Expect(model.MustParseDecimal("1.23").Floor(step).String()).To(Equal("1.20"))
+		Expect(model.MustParseDecimal("1.23").Ceil(step).String()).To(Equal("1.25"))
+		Expect(model.MustParseDecimal("1.25").Ceil(step).String()).To(Equal("1.25"))
+		Expect(model.MustParseDecimal("-1.23").Floor(step).String()).To(Equal("-1.25"))
+		Expect(model.MustParseDecimal("1.23").Floor(model.Decimal{}).String()).To(Equal("1.23"))
+	})
+
+	It("should (un)marshal Binance JSON numbers", func() {
+		var b model.Balance
+		Expect(json.Unmarshal([]byte(`{"asset":"BTC","free":"0.12345678","locked":"1.00000001"}`), &b)).To(Succeed())
+		Expect(b.Total().String()).To(Equal("1.12345679"))
+
+		var v struct {
+			A model.Decimal `json:"a"`
+			B model.Decimal `json:"b"`
+			C model.Decimal `json:"c"`
+		}
+		Expect(json.Unmarshal([]byte(`{"a":1.5,"b":null,"c":""}`), &v)).To(Succeed())
+		Expect(v.A.String()).To(Equal("1.5"))
+		Expect(v.B.IsZero()).To(BeTrue())
+
+		out, err := json.Marshal(b)
+		Expect(err).To(BeNil())
+		Expect(string(out)).To(Equal(`{"asset":"BTC","free":"0.12345678","locked":"1.00000001"}`))
+	})
+})
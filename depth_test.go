@@ -0,0 +1,90 @@
+package binance_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance"
+	"github.com/jaztec/go-binance/model"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("Depth", func() {
+	It("should decode the price levels", func() {
+		ts := testServer("/api/v3/depth", map[string]struct{}{
+			"symbol": {},
+			"limit":  {},
+		}, http.StatusOK, loadFixture("depth_data"), nil)
+		defer ts.Close()
+
+		o, err := newAPI(ts.URL).Depth("BNBBTC", 5)
+		Expect(err).To(BeNil())
+		Expect(o.LastUpdateID).To(Equal(1027024))
+		Expect(o.Bids).To(HaveLen(3))
+		Expect(o.Bids[0].Price.String()).To(Equal("4.00000000"))
+		Expect(o.Bids[0].Qty.String()).To(Equal("431.00000000"))
+		Expect(o.Asks[2].Qty.String()).To(Equal("50.00000000"))
+	})
+
+	It("should accept any limit up to 5000", func() {
+		var limit string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit = r.URL.Query().Get("limit")
+			_, _ = w.Write(loadFixture("depth_data"))
+		}))
+		defer ts.Close()
+
+		_, err := newAPI(ts.URL).Depth("BNBBTC", 200)
+		Expect(err).To(BeNil())
+		Expect(limit).To(Equal("200"))
+	})
+
+	It("should refuse limits Binance does not accept", func() {
+		_, err := newAPI("http://mies.mees").Depth("BNBBTC", 5001)
+		Expect(errors.Is(err, binance.InvalidDepthLimit)).To(BeTrue())
+		Expect(err).To(MatchError("invalid depth limit 5001, valid limits are 1 to 5000"))
+
+		_, err = newAPI("http://mies.mees").Depth("BNBBTC", -1)
+		Expect(errors.Is(err, binance.InvalidDepthLimit)).To(BeTrue())
+	})
+
+	It("should summarise the book", func() {
+		var o model.Orders
+		Expect(json.Unmarshal(loadFixture("depth_data"), &o)).To(Succeed())
+
+		bid, ok := o.BestBid()
+		Expect(ok).To(BeTrue())
+		Expect(bid.Price.String()).To(Equal("4.00000000"))
+		ask, ok := o.BestAsk()
+		Expect(ok).To(BeTrue())
+		Expect(ask.Price.String()).To(Equal("4.00000200"))
+
+		spread, _ := o.Spread()
+		Expect(spread.String()).To(Equal("0.00000200"))
+		mid, _ := o.Mid()
+		Expect(mid.String()).To(Equal("4.000001000"))
+
+		// 1% around 4.000001 holds the levels from 3.96000099 up to 4.04000101
+		bids, asks := o.VolumeWithin(model.MustParseDecimal("1"))
+		Expect(bids.String()).To(Equal("441.00000000"))
+		Expect(asks.String()).To(Equal("15.00000000"))
+
+		b, err := json.Marshal(o.Bids[0])
+		Expect(err).To(BeNil())
+		Expect(string(b)).To(Equal(`["4.00000000","431.00000000"]`))
+	})
+
+	It("should handle an empty book", func() {
+		var o model.Orders
+		_, ok := o.Mid()
+		Expect(ok).To(BeFalse())
+		bids, asks := o.VolumeWithin(model.MustParseDecimal("1"))
+		Expect(bids.IsZero()).To(BeTrue())
+		Expect(asks.IsZero()).To(BeTrue())
+	})
+})
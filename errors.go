@@ -1,15 +1,128 @@
 package binance
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
 
 	"github.com/jaztec/go-binance/model"
 )
 
+// ErrorCode is a error code as returned by the Binance API. It implements the error
+// interface so it can be used as target in errors.Is:
+//
+//	if errors.Is(err, binance.ErrCodeInsufficientBalance) { ... }
+type ErrorCode int
+
+// Satisfy the Error interface
+func (c ErrorCode) Error() string {
+	return fmt.Sprintf("code=%d", int(c))
+}
+
+const (
+	// ErrCodeUnknown an unknown error occurred while processing the request
+	ErrCodeUnknown ErrorCode = -1000
+	// ErrCodeDisconnected internal error, unable to process the request
+	ErrCodeDisconnected ErrorCode = -1001
+	// ErrCodeUnauthorized you are not authorized to execute this request
+	ErrCodeUnauthorized ErrorCode = -1002
+	// ErrCodeTooManyRequests too many requests, the rate limit is exceeded
+	ErrCodeTooManyRequests ErrorCode = -1003
+	// ErrCodeServerBusy the server is busy, try again later
+	ErrCodeServerBusy ErrorCode = -1004
+	// ErrCodeUnexpectedResponse an unexpected response was received from the message bus
+	ErrCodeUnexpectedResponse ErrorCode = -1006
+	// ErrCodeTimeout waiting for the backend server timed out, the execution status is unknown
+	ErrCodeTimeout ErrorCode = -1007
+	// ErrCodeServerOverloaded the server is currently overloaded with other requests
+	ErrCodeServerOverloaded ErrorCode = -1008
+	// ErrCodeFilterFailure the request failed one of the symbol or exchange filters
+	ErrCodeFilterFailure ErrorCode = -1013
+	// ErrCodeUnknownOrderComposition the combination of order parameters is not supported
+	ErrCodeUnknownOrderComposition ErrorCode = -1014
+	// ErrCodeTooManyOrders too many new orders, the order rate limit is exceeded
+	ErrCodeTooManyOrders ErrorCode = -1015
+	// ErrCodeServiceShuttingDown the service is no longer available
+	ErrCodeServiceShuttingDown ErrorCode = -1016
+	// ErrCodeUnsupportedOperation this operation is not supported
+	ErrCodeUnsupportedOperation ErrorCode = -1020
+	// ErrCodeInvalidTimestamp the timestamp is outside of the recvWindow or ahead of the server time
+	ErrCodeInvalidTimestamp ErrorCode = -1021
+	// ErrCodeInvalidSignature the signature of the request is not valid
+	ErrCodeInvalidSignature ErrorCode = -1022
+	// ErrCodeIllegalChars illegal characters found in a parameter
+	ErrCodeIllegalChars ErrorCode = -1100
+	// ErrCodeTooManyParameters too many parameters were sent
+	ErrCodeTooManyParameters ErrorCode = -1101
+	// ErrCodeMandatoryParamEmptyOrMalformed a mandatory parameter was not sent, empty or malformed
+	ErrCodeMandatoryParamEmptyOrMalformed ErrorCode = -1102
+	// ErrCodeUnknownParam an unknown parameter was sent
+	ErrCodeUnknownParam ErrorCode = -1103
+	// ErrCodeUnreadParameters not all sent parameters were read
+	ErrCodeUnreadParameters ErrorCode = -1104
+	// ErrCodeParamEmpty a parameter was empty
+	ErrCodeParamEmpty ErrorCode = -1105
+	// ErrCodeParamNotRequired a parameter was sent when not required
+	ErrCodeParamNotRequired ErrorCode = -1106
+	// ErrCodeBadPrecision the precision is over the maximum defined for the asset
+	ErrCodeBadPrecision ErrorCode = -1111
+	// ErrCodeNoDepth no orders on the book for the symbol
+	ErrCodeNoDepth ErrorCode = -1112
+	// ErrCodeTIFNotRequired timeInForce is not required for the order type
+	ErrCodeTIFNotRequired ErrorCode = -1114
+	// ErrCodeInvalidTIF invalid timeInForce
+	ErrCodeInvalidTIF ErrorCode = -1115
+	// ErrCodeInvalidOrderType invalid order type
+	ErrCodeInvalidOrderType ErrorCode = -1116
+	// ErrCodeInvalidSide invalid side
+	ErrCodeInvalidSide ErrorCode = -1117
+	// ErrCodeEmptyNewClientOrderID the new client order id was empty
+	ErrCodeEmptyNewClientOrderID ErrorCode = -1118
+	// ErrCodeEmptyOrigClientOrderID the original client order id was empty
+	ErrCodeEmptyOrigClientOrderID ErrorCode = -1119
+	// ErrCodeBadInterval invalid interval
+	ErrCodeBadInterval ErrorCode = -1120
+	// ErrCodeBadSymbol invalid symbol
+	ErrCodeBadSymbol ErrorCode = -1121
+	// ErrCodeInvalidListenKey the listen key does not exist
+	ErrCodeInvalidListenKey ErrorCode = -1125
+	// ErrCodeMoreThanXXHours the lookup interval is too big
+	ErrCodeMoreThanXXHours ErrorCode = -1127
+	// ErrCodeOptionalParamsBadCombo the combination of optional parameters is invalid
+	ErrCodeOptionalParamsBadCombo ErrorCode = -1128
+	// ErrCodeInvalidParameter invalid data sent for a parameter
+	ErrCodeInvalidParameter ErrorCode = -1130
+	// ErrCodeNewOrderRejected the new order was rejected, e.g. because of an insufficient balance
+	ErrCodeNewOrderRejected ErrorCode = -2010
+	// ErrCodeInsufficientBalance is reported by Binance as a rejected new order
+	ErrCodeInsufficientBalance = ErrCodeNewOrderRejected
+	// ErrCodeCancelRejected the cancel request was rejected, e.g. because of an unknown order
+	ErrCodeCancelRejected ErrorCode = -2011
+	// ErrCodeUnknownOrder is reported by Binance as a rejected cancel request
+	ErrCodeUnknownOrder = ErrCodeCancelRejected
+	// ErrCodeNoSuchOrder the order does not exist
+	ErrCodeNoSuchOrder ErrorCode = -2013
+	// ErrCodeBadAPIKeyFormat the API key format is invalid
+	ErrCodeBadAPIKeyFormat ErrorCode = -2014
+	// ErrCodeRejectedAPIKey invalid API key, IP or permissions for the action
+	ErrCodeRejectedAPIKey ErrorCode = -2015
+	// ErrCodeNoTradingWindow no trading window could be found for the symbol
+	ErrCodeNoTradingWindow ErrorCode = -2016
+	// ErrCodeCancelReplacePartiallyFailed either the cancel or the new order of a cancel-replace failed
+	ErrCodeCancelReplacePartiallyFailed ErrorCode = -2021
+	// ErrCodeCancelReplaceFailed both the cancel and the new order of a cancel-replace failed
+	ErrCodeCancelReplaceFailed ErrorCode = -2022
+)
+
 // APIError encapsulates some expected errors
 type APIError struct {
-	msg string
-	err *model.Error
+	msg    string
+	status int
+	err    *model.Error
 }
 
 // Satisfy the Error interface
@@ -20,6 +133,75 @@ func (bae APIError) Error() string {
 	return fmt.Sprintf("msg=%s", bae.msg)
 }
 
+// Code returns the error code Binance returned, zero when the error did not originate from Binance
+func (bae APIError) Code() ErrorCode {
+	if bae.err == nil {
+		return 0
+	}
+	return ErrorCode(bae.err.Code)
+}
+
+// Message returns the message describing the error
+func (bae APIError) Message() string {
+	if bae.err != nil {
+		return bae.err.Msg
+	}
+	return bae.msg
+}
+
+// StatusCode returns the HTTP status of the response, zero when the error did not originate from Binance
+func (bae APIError) StatusCode() int {
+	return bae.status
+}
+
+// Is allows matching the error against an ErrorCode with errors.Is
+func (bae APIError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && bae.err != nil && bae.Code() == code
+}
+
+// Unwrap returns the model.Error returned by Binance, if any
+func (bae APIError) Unwrap() error {
+	if bae.err == nil {
+		return nil
+	}
+	return bae.err
+}
+
+// CancelReplaceError is returned when a step of a cancel-replace failed. Response holds
+// the outcome of both steps.
+type CancelReplaceError struct {
+	APIError
+	Response model.CancelReplaceResponse
+}
+
+// Unwrap returns the APIError, allowing matching against the error codes
+func (cre CancelReplaceError) Unwrap() error {
+	return cre.APIError
+}
+
+// FilterError is returned when an order is validated locally and violates one of the
+// filters of its symbol. It matches ErrCodeFilterFailure, like the error Binance would return.
+// Filter is empty when the order violates a rule of the symbol itself, like its trading
+// status, the allowed order types or whether iceberg orders are allowed.
+type FilterError struct {
+	Symbol string
+	Filter model.FilterType
+	Reason string
+}
+
+func (fe FilterError) Error() string {
+	if fe.Filter == "" {
+		return fmt.Sprintf("order on %s is not allowed: %s", fe.Symbol, fe.Reason)
+	}
+	return fmt.Sprintf("order on %s violates %s: %s", fe.Symbol, fe.Filter, fe.Reason)
+}
+
+// Is reports a match with ErrCodeFilterFailure
+func (fe FilterError) Is(target error) bool {
+	return target == ErrCodeFilterFailure
+}
+
 var (
 	// TooMuchCalls to API, hold back to prevent a ban
 	TooMuchCalls = APIError{msg: "too much calls to API"}
@@ -27,6 +209,72 @@ var (
 	Blocked = APIError{msg: "IP ban active"}
 	// AtTimeout for too many calls to the API
 	AtTimeout = APIError{msg: "API in timeout now"}
+	// LimitExceeded when a call would exceed the API rate limits
+	LimitExceeded = APIError{msg: "call would exceed API rate limits"}
 	// NoSymbolProvided in a call that requires one
 	NoSymbolProvided = APIError{msg: "no symbol provided"}
+	// NoOrderIDProvided in a call that requires an order id or client order id
+	NoOrderIDProvided = APIError{msg: "no orderId or origClientOrderId provided"}
+	// NoAPIKeyProvided in the configuration while calling an endpoint that requires one
+	NoAPIKeyProvided = APIError{msg: "no API key provided"}
+	// NoIntervalProvided in a call that requires a kline interval
+	NoIntervalProvided = APIError{msg: "no interval provided"}
+	// NoStartTimeProvided in a call that requires a start time
+	NoStartTimeProvided = APIError{msg: "no start time provided"}
+	// InvalidDepthLimit when a depth limit is requested that Binance does not accept
+	InvalidDepthLimit = APIError{msg: "invalid depth limit"}
 )
+
+// retryableCodes hold the Binance error codes reporting a transient problem
+var retryableCodes = map[ErrorCode]struct{}{
+	ErrCodeDisconnected:       {},
+	ErrCodeTooManyRequests:    {},
+	ErrCodeServerBusy:         {},
+	ErrCodeUnexpectedResponse: {},
+	ErrCodeTimeout:            {},
+	ErrCodeServerOverloaded:   {},
+}
+
+// IsRetryable reports whether the error is caused by a transient problem and the call can
+// be made again. Note that for calls placing orders the execution status may be unknown.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, TooMuchCalls) {
+		return true
+	}
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.status >= http.StatusInternalServerError {
+			return true
+		}
+		_, ok := retryableCodes[apiErr.Code()]
+		return ok
+	}
+	return isTransientNetError(err)
+}
+
+// isTransientNetError reports whether a network error is likely to go away on a new attempt, like
+// a timeout or a dropped connection. TLS, certificate and malformed URL errors are not.
+func isTransientNetError(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsRateLimit reports whether the error is caused by violating, or nearly violating, the API rate limits
+func IsRateLimit(err error) bool {
+	for _, target := range []error{TooMuchCalls, Blocked, AtTimeout, LimitExceeded, ErrCodeTooManyRequests, ErrCodeTooManyOrders} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.status == http.StatusTooManyRequests || apiErr.status == http.StatusTeapot
+	}
+	return false
+}
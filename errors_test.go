@@ -0,0 +1,85 @@
+package binance_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance"
+	"github.com/jaztec/go-binance/model"
+)
+
+var _ = Describe("Errors", func() {
+	It("should expose the Binance error code and status", func() {
+		ts := testServer("/api/v3/order", nil, http.StatusBadRequest,
+			[]byte(`{"code":-2010,"msg":"Account has insufficient balance for requested action."}`), nil)
+		defer ts.Close()
+
+		a := newAPI(ts.URL)
+		_, err := a.Order("ETHBTC", model.Buy, model.Market, binance.OrderParams{Quantity: model.MustParseDecimal("1")})
+		Expect(err).ToNot(BeNil())
+		Expect(errors.Is(err, binance.ErrCodeInsufficientBalance)).To(BeTrue())
+		Expect(errors.Is(err, binance.ErrCodeInvalidTimestamp)).To(BeFalse())
+
+		var apiErr binance.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Code()).To(Equal(binance.ErrCodeNewOrderRejected))
+		Expect(apiErr.StatusCode()).To(Equal(http.StatusBadRequest))
+		Expect(apiErr.Message()).To(Equal("Account has insufficient balance for requested action."))
+
+		var modelErr *model.Error
+		Expect(errors.As(err, &modelErr)).To(BeTrue())
+		Expect(modelErr.Code).To(Equal(-2010))
+
+		Expect(binance.IsRetryable(err)).To(BeFalse())
+		Expect(binance.IsRateLimit(err)).To(BeFalse())
+	})
+
+	It("should classify transient errors as retryable", func() {
+		ts := testServer("/api/v3/avgPrice", nil, http.StatusServiceUnavailable,
+			[]byte(`{"code":-1001,"msg":"Internal error; unable to process your request. Please try again."}`), nil)
+		defer ts.Close()
+
+		_, err := newAPI(ts.URL).AvgPrice("ETHBTC")
+		Expect(errors.Is(err, binance.ErrCodeDisconnected)).To(BeTrue())
+		Expect(binance.IsRetryable(err)).To(BeTrue())
+	})
+
+	It("should only retry network errors that can go away", func() {
+		ts := httptest.NewServer(http.NotFoundHandler())
+		uri := ts.URL
+		ts.Close()
+		_, err := newAPI(uri).AvgPrice("ETHBTC")
+		Expect(errors.Is(err, syscall.ECONNREFUSED)).To(BeTrue())
+		Expect(binance.IsRetryable(err)).To(BeTrue())
+
+		tls := httptest.NewTLSServer(http.NotFoundHandler())
+		defer tls.Close()
+		_, err = newAPI(tls.URL).AvgPrice("ETHBTC")
+		Expect(err).ToNot(BeNil())
+		Expect(binance.IsRetryable(err)).To(BeFalse())
+
+		_, err = newAPI("mies://mees").AvgPrice("ETHBTC")
+		Expect(err).ToNot(BeNil())
+		Expect(binance.IsRetryable(err)).To(BeFalse())
+
+		Expect(binance.IsRetryable(fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF))).To(BeTrue())
+	})
+
+	It("should classify rate limit errors", func() {
+		ts := testServer("/api/v3/avgPrice", nil, http.StatusTooManyRequests,
+			nil, map[string]string{"Retry-After": "1"})
+		defer ts.Close()
+
+		_, err := newAPI(ts.URL).AvgPrice("ETHBTC")
+		Expect(binance.IsRateLimit(err)).To(BeTrue())
+		Expect(binance.IsRateLimit(binance.LimitExceeded)).To(BeTrue())
+		Expect(binance.IsRateLimit(binance.NoSymbolProvided)).To(BeFalse())
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"syscall"
 
 	"github.com/jaztec/go-binance"
+	"github.com/jaztec/go-binance/model"
 )
 
 func main() {
@@ -19,7 +20,7 @@ func main() {
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
 
-	ch, err := b.StreamCaller().Kline(ctx, []string{"ETHBTC"}, "1m")
+	ch, err := b.StreamCaller().Kline(ctx, []string{"ETHBTC"}, model.OneMinute)
 	if err != nil {
 		panic(err)
 	}
@@ -1,17 +1,63 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/jaztec/go-binance/model"
 )
 
 const exchangeInfoPath = "/api/v3/exchangeInfo"
 
+func init() {
+	setWeight(http.MethodGet, exchangeInfoPath, 20)
+}
+
+// ExchangeInfoParams limit the exchange information to a set of symbols or to the symbols
+// having one of the permissions. Symbols and permissions cannot be combined.
+type ExchangeInfoParams struct {
+	Symbols     []string
+	Permissions []string
+}
+
 func (a *api) ExchangeInfo() (ei model.ExchangeInfo, err error) {
-	body, err := a.Request(http.MethodGet, exchangeInfoPath, nil)
+	return a.ExchangeInfoContext(context.Background())
+}
+
+func (a *api) ExchangeInfoContext(ctx context.Context) (ei model.ExchangeInfo, err error) {
+	return a.ExchangeInfoWithParamsContext(ctx, ExchangeInfoParams{})
+}
+
+func (a *api) ExchangeInfoWithParams(params ExchangeInfoParams) (model.ExchangeInfo, error) {
+	return a.ExchangeInfoWithParamsContext(context.Background(), params)
+}
+
+func (a *api) ExchangeInfoWithParamsContext(ctx context.Context, params ExchangeInfoParams) (ei model.ExchangeInfo, err error) {
+	if len(params.Symbols) > 0 && len(params.Permissions) > 0 {
+		return ei, errors.New("symbols and permissions cannot be combined")
+	}
+
+	var p Parameters
+	switch {
+	case len(params.Symbols) == 1:
+		p = NewParameters(1)
+		p.Set("symbol", params.Symbols[0])
+	case len(params.Symbols) > 1:
+		p = NewParameters(1)
+		p.Set("symbols", encodeList(params.Symbols))
+	case len(params.Permissions) == 1:
+		p = NewParameters(1)
+		p.Set("permissions", params.Permissions[0])
+	case len(params.Permissions) > 1:
+		p = NewParameters(1)
+		p.Set("permissions", encodeList(params.Permissions))
+	}
+
+	body, err := a.RequestContext(ctx, http.MethodGet, exchangeInfoPath, p)
 	if err != nil {
 		return
 	}
@@ -22,7 +68,48 @@ func (a *api) ExchangeInfo() (ei model.ExchangeInfo, err error) {
 	}
 
 	// update internal exchange information as well
-	a.exchangeInfo = &ei
+	a.exchangeInfo.store(ei.Symbols)
+	a.checker.setLimits(ei.RateLimits)
 
 	return
 }
+
+func (a *api) Symbol(name string) (model.SymbolInfo, error) {
+	return a.SymbolContext(context.Background(), name)
+}
+
+func (a *api) SymbolContext(ctx context.Context, name string) (model.SymbolInfo, error) {
+	if name == "" {
+		return model.SymbolInfo{}, NoSymbolProvided
+	}
+	name = strings.ToUpper(name)
+	if si, ok := a.exchangeInfo.symbol(name); ok {
+		return si, nil
+	}
+
+	return a.exchangeInfo.fetch(ctx, name, func() (model.SymbolInfo, error) {
+		// only fetch the symbol instead of the entire exchange information
+		ei, err := a.ExchangeInfoWithParamsContext(ctx, ExchangeInfoParams{Symbols: []string{name}})
+		if err != nil {
+			return model.SymbolInfo{}, err
+		}
+		for _, si := range ei.Symbols {
+			if si.Symbol == name {
+				return si, nil
+			}
+		}
+		return model.SymbolInfo{}, fmt.Errorf("symbol %s not found in exchange information: %w", name, ErrCodeBadSymbol)
+	})
+}
+
+// refreshOnOrderError drops the cached symbol information when Binance refused an order
+// because of the symbol or its filters, so the next lookup fetches it again
+func (a *api) refreshOnOrderError(symbol string, err error) {
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		return
+	}
+	if apiErr.Is(ErrCodeBadSymbol) || apiErr.Is(ErrCodeFilterFailure) {
+		a.exchangeInfo.invalidate(strings.ToUpper(symbol))
+	}
+}
@@ -0,0 +1,108 @@
+package binance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+type cachedSymbol struct {
+	info    model.SymbolInfo
+	fetched time.Time
+}
+
+// symbolFetch is a fetch of a symbol in flight, callers missing the same symbol meanwhile
+// wait for its result
+type symbolFetch struct {
+	done chan struct{}
+	info model.SymbolInfo
+	err  error
+}
+
+// exchangeInfoCache keeps the symbol information by name. Entries older than the ttl are
+// not returned and removed, a zero ttl keeps them until they are invalidated.
+type exchangeInfoCache struct {
+	mut     sync.Mutex
+	ttl     time.Duration
+	symbols map[string]cachedSymbol
+	fetches map[string]*symbolFetch
+}
+
+func newExchangeInfoCache(ttl time.Duration) *exchangeInfoCache {
+	return &exchangeInfoCache{
+		ttl:     ttl,
+		symbols: make(map[string]cachedSymbol),
+		fetches: make(map[string]*symbolFetch),
+	}
+}
+
+func (c *exchangeInfoCache) store(symbols []model.SymbolInfo) {
+	now := time.Now()
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for name, cs := range c.symbols {
+		if c.expired(cs, now) {
+			delete(c.symbols, name)
+		}
+	}
+	for _, si := range symbols {
+		c.symbols[si.Symbol] = cachedSymbol{info: si, fetched: now}
+	}
+}
+
+func (c *exchangeInfoCache) symbol(name string) (model.SymbolInfo, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	cs, ok := c.symbols[name]
+	if !ok {
+		return model.SymbolInfo{}, false
+	}
+	if c.expired(cs, time.Now()) {
+		delete(c.symbols, name)
+		return model.SymbolInfo{}, false
+	}
+	return cs.info, true
+}
+
+// fetch calls fn to get the symbol, unless a fetch of the symbol is already in flight. In that
+// case the result of the running fetch is returned.
+func (c *exchangeInfoCache) fetch(ctx context.Context, name string, fn func() (model.SymbolInfo, error)) (model.SymbolInfo, error) {
+	c.mut.Lock()
+	if f, ok := c.fetches[name]; ok {
+		c.mut.Unlock()
+		select {
+		case <-f.done:
+			return f.info, f.err
+		case <-ctx.Done():
+			return model.SymbolInfo{}, ctx.Err()
+		}
+	}
+	f := &symbolFetch{done: make(chan struct{})}
+	c.fetches[name] = f
+	c.mut.Unlock()
+
+	f.info, f.err = fn()
+
+	c.mut.Lock()
+	delete(c.fetches, name)
+	c.mut.Unlock()
+	close(f.done)
+
+	return f.info, f.err
+}
+
+func (c *exchangeInfoCache) invalidate(name string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	delete(c.symbols, name)
+}
+
+func (c *exchangeInfoCache) expired(cs cachedSymbol, now time.Time) bool {
+	return c.ttl > 0 && now.Sub(cs.fetched) > c.ttl
+}
@@ -0,0 +1,191 @@
+package binance_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance"
+	"github.com/jaztec/go-binance/model"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("ExchangeInfo", func() {
+	It("should decode the filters into their types", func() {
+		ts := testServer("/api/v3/exchangeInfo", nil, http.StatusOK, loadFixture("exchange_info_data"), nil)
+		defer ts.Close()
+
+		ei, err := newAPI(ts.URL).ExchangeInfo()
+		Expect(err).To(BeNil())
+		Expect(ei.ExchangeFilters).To(Equal(model.Filters{model.ExchangeMaxNumOrders{MaxNumOrders: 1000}}))
+
+		filters := ei.Symbols[0].Filters
+		Expect(filters).To(HaveLen(10))
+		Expect(filters.Find(model.PriceFilterType)).To(Equal(model.PriceFilter{
+			MinPrice: model.MustParseDecimal("0.00001000"),
+			MaxPrice: model.MustParseDecimal("1000.00000000"),
+			TickSize: model.MustParseDecimal("0.00001000"),
+		}))
+		lot, ok := filters.Find(model.LotSizeType).(model.LotSize)
+		Expect(ok).To(BeTrue())
+		Expect(lot.StepSize.String()).To(Equal("1.00000000"))
+		Expect(filters.Find(model.TrailingDeltaType).(model.TrailingDelta).MaxTrailingBelowDelta).To(Equal(int64(2000)))
+		Expect(filters.Find(model.NotionalType).(model.Notional).ApplyMinToMarket).To(BeTrue())
+		Expect(filters.Find(model.MaxPositionType)).To(BeNil())
+
+		unknown, ok := filters.Find("SOMETHING_NEW").(model.UnknownFilter)
+		Expect(ok).To(BeTrue())
+		Expect(string(unknown.Raw)).To(ContainSubstring(`"value": "42"`))
+	})
+
+	It("should encode the filters including their type", func() {
+		b, err := json.Marshal(model.Filters{model.IcebergParts{Limit: 10}})
+		Expect(err).To(BeNil())
+		Expect(string(b)).To(Equal(`[{"filterType":"ICEBERG_PARTS","limit":10}]`))
+
+		var fs model.Filters
+		Expect(json.Unmarshal(b, &fs)).To(Succeed())
+		Expect(fs).To(Equal(model.Filters{model.IcebergParts{Limit: 10}}))
+	})
+
+	It("should round prices and quantities to the filters", func() {
+		var ei model.ExchangeInfo
+		Expect(json.Unmarshal(loadFixture("exchange_info_data"), &ei)).To(Succeed())
+		si := ei.Symbols[0]
+
+		price := model.MustParseDecimal("0.123456")
+		Expect(si.RoundPrice(price, model.Buy).String()).To(Equal("0.12345000"))
+		Expect(si.RoundPrice(price, model.Sell).String()).To(Equal("0.12346000"))
+		Expect(si.RoundQty(model.MustParseDecimal("12.9")).String()).To(Equal("12.00000000"))
+
+		// 5 USDT at 0.3 is 16.67 DOGE, rounded up to the stepSize
+		Expect(si.MinQtyForNotional(model.MustParseDecimal("0.3")).String()).To(Equal("17.00000000"))
+		// the minQty wins from the notional at high prices
+		Expect(si.MinQtyForNotional(model.MustParseDecimal("100")).String()).To(Equal("1.00000000"))
+	})
+
+	Context("Should cache the symbol information", func() {
+		var (
+			ts      *httptest.Server
+			fetches []url.Values
+		)
+
+		BeforeEach(func() {
+			fetches = nil
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v3/exchangeInfo" {
+					fetches = append(fetches, r.URL.Query())
+					_, _ = w.Write(loadFixture("exchange_info_data"))
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"code":-1013,"msg":"Filter failure: LOT_SIZE"}`))
+			}))
+		})
+
+		AfterEach(func() {
+			ts.Close()
+		})
+
+		It("should only fetch the requested symbol once", func() {
+			a := newAPI(ts.URL)
+			si, err := a.Symbol("DOGEUSDT")
+			Expect(err).To(BeNil())
+			Expect(si.BaseAsset).To(Equal("DOGE"))
+			_, err = a.Symbol("DOGEUSDT")
+			Expect(err).To(BeNil())
+
+			Expect(fetches).To(HaveLen(1))
+			Expect(fetches[0].Get("symbol")).To(Equal("DOGEUSDT"))
+		})
+
+		It("should look up symbols regardless of their case", func() {
+			a := newAPI(ts.URL)
+			si, err := a.Symbol("dogeusdt")
+			Expect(err).To(BeNil())
+			Expect(si.Symbol).To(Equal("DOGEUSDT"))
+			_, err = a.Symbol("DOGEUSDT")
+			Expect(err).To(BeNil())
+
+			Expect(fetches).To(HaveLen(1))
+			Expect(fetches[0].Get("symbol")).To(Equal("DOGEUSDT"))
+		})
+
+		It("should fetch a symbol missed concurrently once", func() {
+			var calls int32
+			release := make(chan struct{})
+			slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				_, _ = w.Write(loadFixture("exchange_info_data"))
+			}))
+			defer slow.Close()
+
+			a := newAPI(slow.URL)
+			var wg sync.WaitGroup
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, err := a.Symbol("DOGEUSDT")
+					Expect(err).To(BeNil())
+				}()
+			}
+			Eventually(func() int32 {
+				return atomic.LoadInt32(&calls)
+			}, time.Second).Should(Equal(int32(1)))
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+
+		It("should fetch the symbol again after the ttl", func() {
+			a, err := binance.NewAPICaller(binance.APIConfig{BaseURI: ts.URL, ExchangeInfoTTL: time.Millisecond})
+			Expect(err).To(BeNil())
+			_, err = a.Symbol("DOGEUSDT")
+			Expect(err).To(BeNil())
+			time.Sleep(2 * time.Millisecond)
+			_, err = a.Symbol("DOGEUSDT")
+			Expect(err).To(BeNil())
+			Expect(fetches).To(HaveLen(2))
+		})
+
+		It("should fetch the symbol again after a filter failure", func() {
+			a := newAPI(ts.URL)
+			_, err := a.Symbol("DOGEUSDT")
+			Expect(err).To(BeNil())
+
+			_, err = a.Order("DOGEUSDT", model.Sell, model.Market, binance.OrderParams{Quantity: model.MustParseDecimal("1")})
+			Expect(errors.Is(err, binance.ErrCodeFilterFailure)).To(BeTrue())
+
+			_, err = a.Symbol("DOGEUSDT")
+			Expect(err).To(BeNil())
+			Expect(fetches).To(HaveLen(2))
+		})
+
+		It("should pass a list of symbols as JSON array", func() {
+			a := newAPI(ts.URL)
+			_, err := a.ExchangeInfoWithParams(binance.ExchangeInfoParams{Symbols: []string{"DOGEUSDT", "BTCUSDT"}})
+			Expect(err).To(BeNil())
+			Expect(fetches[0].Get("symbols")).To(Equal(`["DOGEUSDT","BTCUSDT"]`))
+
+			_, err = a.ExchangeInfoWithParams(binance.ExchangeInfoParams{Permissions: []string{"SPOT"}})
+			Expect(err).To(BeNil())
+			Expect(fetches[1].Get("permissions")).To(Equal("SPOT"))
+
+			_, err = a.ExchangeInfoWithParams(binance.ExchangeInfoParams{Symbols: []string{"DOGEUSDT"}, Permissions: []string{"SPOT"}})
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
@@ -9,7 +9,7 @@ import (
 	"github.com/jaztec/go-binance/model"
 )
 
-func (s *streamer) Kline(ctx context.Context, symbols []string, interval string) (<-chan model.KlineData, error) {
+func (s *streamer) Kline(ctx context.Context, symbols []string, interval model.KlineInterval) (<-chan model.KlineData, error) {
 	params := make([]string, 0, len(symbols))
 	for _, s := range symbols {
 		params = append(params, fmt.Sprintf("%s@kline_%s", strings.ToLower(s), interval))
@@ -0,0 +1,135 @@
+package binance
+
+import (
+	"context"
+	"time"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+// klinesPageLimit is the maximum amount of klines Binance returns per call
+const klinesPageLimit = 1000
+
+// KlineIterator walks the klines of a symbol in a time range page by page, only a single
+// page is held in memory. The requests wait for the rate limits instead of failing on them,
+// and when Binance rejects a page for exceeding them, or bans the IP, the page is requested
+// again after the ban ends. A start time is required, without it Binance only returns the
+// last page before the end time, so the iterator reports NoStartTimeProvided instead.
+//
+//	it := a.KlineIterator(ctx, "ETHBTC", model.OneMinute, start, end)
+//	for it.Next() {
+//		k := it.Kline()
+//	}
+//	if err := it.Err(); err != nil {
+//		// the walk was interrupted
+//	}
+type KlineIterator struct {
+	api      *api
+	ctx      context.Context
+	symbol   string
+	interval model.KlineInterval
+	next     int64
+	end      int64
+
+	page    []model.Kline
+	pos     int
+	current model.Kline
+	seen    bool
+	done    bool
+	err     error
+}
+
+func (a *api) KlineIterator(ctx context.Context, symbol string, interval model.KlineInterval, startTime, endTime int64) *KlineIterator {
+	it := &KlineIterator{
+		api:      a,
+		ctx:      WithWaitOnRateLimit(ctx),
+		symbol:   symbol,
+		interval: interval,
+		next:     startTime,
+		end:      endTime,
+	}
+	if startTime == 0 {
+		it.err = NoStartTimeProvided
+	}
+	return it
+}
+
+// Next advances to the next kline, fetching a new page when needed. It returns false when
+// the range is exhausted or an error occurred.
+func (it *KlineIterator) Next() bool {
+	for {
+		for it.pos < len(it.page) {
+			k := it.page[it.pos]
+			it.pos++
+			// pages may overlap on their boundaries
+			if it.seen && k.StartTime <= it.current.StartTime {
+				continue
+			}
+			it.current = k
+			it.seen = true
+			return true
+		}
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+}
+
+// Kline returns the kline Next advanced to
+func (it *KlineIterator) Kline() model.Kline {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *KlineIterator) Err() error {
+	return it.err
+}
+
+func (it *KlineIterator) fetch() {
+	var (
+		page []model.Kline
+		err  error
+	)
+	for attempt := 1; ; attempt++ {
+		page, err = it.api.klines(it.ctx, klinesPath, it.symbol, it.interval, it.next, it.end, klinesPageLimit, "")
+		if err == nil {
+			break
+		}
+		if !IsRateLimit(err) {
+			it.err = err
+			return
+		}
+
+		// honor the Retry-After period Binance has set
+		delay := it.api.cfg.Retry.backoff(attempt)
+		if d := time.Until(it.api.checker.banned()); d > delay {
+			delay = d
+		}
+		_ = it.api.logger.Log("method", "KlineIterator", "delay", delay, "error", err)
+		if err := wait(it.ctx, delay); err != nil {
+			it.err = err
+			return
+		}
+	}
+	it.page, it.pos = page, 0
+
+	if len(page) < klinesPageLimit {
+		it.done = true
+		return
+	}
+	it.next = page[len(page)-1].CloseTime + 1
+	if it.end != 0 && it.next > it.end {
+		it.done = true
+	}
+}
+
+func (a *api) WalkKlines(ctx context.Context, symbol string, interval model.KlineInterval, startTime, endTime int64, fn func(model.Kline) error) error {
+	it := a.KlineIterator(ctx, symbol, interval, startTime, endTime)
+	for it.Next() {
+		if err := fn(it.Kline()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
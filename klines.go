@@ -0,0 +1,80 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+const (
+	klinesPath   = "/api/v3/klines"
+	uiKlinesPath = "/api/v3/uiKlines"
+)
+
+func init() {
+	setWeight(http.MethodGet, klinesPath, 2)
+	setWeight(http.MethodGet, uiKlinesPath, 2)
+}
+
+func (a *api) Klines(symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) ([]model.Kline, error) {
+	return a.KlinesContext(context.Background(), symbol, interval, startTime, endTime, limit, timeZone)
+}
+
+func (a *api) KlinesContext(ctx context.Context, symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) ([]model.Kline, error) {
+	return a.klines(ctx, klinesPath, symbol, interval, startTime, endTime, limit, timeZone)
+}
+
+func (a *api) UIKlines(symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) ([]model.Kline, error) {
+	return a.UIKlinesContext(context.Background(), symbol, interval, startTime, endTime, limit, timeZone)
+}
+
+func (a *api) UIKlinesContext(ctx context.Context, symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) ([]model.Kline, error) {
+	return a.klines(ctx, uiKlinesPath, symbol, interval, startTime, endTime, limit, timeZone)
+}
+
+func (a *api) klines(ctx context.Context, path, symbol string, interval model.KlineInterval, startTime, endTime int64, limit int, timeZone string) (k []model.Kline, err error) {
+	if symbol == "" {
+		return k, NoSymbolProvided
+	}
+	if interval == "" {
+		return k, NoIntervalProvided
+	}
+	q := NewParameters(6)
+	q.Set("symbol", symbol)
+	q.Set("interval", string(interval))
+	if startTime != 0 {
+		q.Set("startTime", strconv.FormatInt(startTime, 10))
+	}
+	if endTime != 0 {
+		q.Set("endTime", strconv.FormatInt(endTime, 10))
+	}
+	if limit != 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if timeZone != "" {
+		q.Set("timeZone", url.QueryEscape(timeZone))
+	}
+
+	body, err := a.RequestContext(ctx, http.MethodGet, path, q)
+	if err != nil {
+		return k, err
+	}
+
+	err = json.Unmarshal(body, &k)
+	if err != nil {
+		return k, fmt.Errorf("encountered error while unmarshaling '%s' into model.Kline", body)
+	}
+
+	// the rows do not hold the symbol and interval they belong to
+	for i := range k {
+		k[i].Symbol = symbol
+		k[i].Interval = interval
+	}
+
+	return k, nil
+}
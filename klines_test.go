@@ -0,0 +1,173 @@
+package binance_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance"
+	"github.com/jaztec/go-binance/model"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("Klines", func() {
+	It("should decode the kline rows", func() {
+		ts := testServer("/api/v3/klines", map[string]struct{}{
+			"symbol":    {},
+			"interval":  {},
+			"startTime": {},
+			"limit":     {},
+			"timeZone":  {},
+		}, http.StatusOK, loadFixture("klines_data"), nil)
+		defer ts.Close()
+
+		k, err := newAPI(ts.URL).Klines("ETHBTC", model.OneWeek, 1499040000000, 0, 2, "+08:00")
+		Expect(err).To(BeNil())
+		Expect(k).To(HaveLen(2))
+		Expect(k[0].Symbol).To(Equal("ETHBTC"))
+		Expect(k[0].Interval).To(Equal(model.OneWeek))
+		Expect(k[0].StartTime).To(Equal(int64(1499040000000)))
+		Expect(k[0].CloseTime).To(Equal(int64(1499644799999)))
+		Expect(k[0].OpenPrice.String()).To(Equal("0.01634790"))
+		Expect(k[0].HighPrice.String()).To(Equal("0.80000000"))
+		Expect(k[0].LowPrice.String()).To(Equal("0.01575800"))
+		Expect(k[0].ClosePrice.String()).To(Equal("0.01577100"))
+		Expect(k[0].BaseAssetVolume.String()).To(Equal("148976.11427815"))
+		Expect(k[0].QuoteAssetVolume.String()).To(Equal("2434.19055334"))
+		Expect(k[0].NumberOfTrades).To(Equal(308))
+		Expect(k[0].TakerBuyBaseAssetVolume.String()).To(Equal("1756.87402397"))
+		Expect(k[0].TakerBuyQuoteAssetVolume.String()).To(Equal("28.46694368"))
+	})
+
+	It("should call the uiKlines endpoint", func() {
+		ts := testServer("/api/v3/uiKlines", map[string]struct{}{
+			"symbol":   {},
+			"interval": {},
+		}, http.StatusOK, loadFixture("klines_data"), nil)
+		defer ts.Close()
+
+		k, err := newAPI(ts.URL).UIKlines("ETHBTC", model.OneWeek, 0, 0, 0, "")
+		Expect(err).To(BeNil())
+		Expect(k).To(HaveLen(2))
+	})
+
+	It("should require a symbol and interval", func() {
+		a := newAPI("http://mies.mees")
+		_, err := a.Klines("", model.OneMinute, 0, 0, 0, "")
+		Expect(err).To(Equal(binance.NoSymbolProvided))
+		_, err = a.Klines("ETHBTC", "", 0, 0, 0, "")
+		Expect(err).To(Equal(binance.NoIntervalProvided))
+	})
+
+	It("should still decode the stream payload", func() {
+		var kd model.KlineData
+		Expect(json.Unmarshal([]byte(`{"e":"kline","E":123456789,"s":"BNBBTC","k":{"t":123400000,"T":123460000,"s":"BNBBTC","i":"1m","f":100,"L":200,"o":"0.0010","c":"0.0020","h":"0.0025","l":"0.0015","v":"1000","n":100,"x":false,"q":"1.0000","V":"500","Q":"0.500"}}`), &kd)).To(Succeed())
+		Expect(kd.Kline.Interval).To(Equal(model.OneMinute))
+		Expect(kd.Kline.StartTime).To(Equal(int64(123400000)))
+		Expect(kd.Kline.CloseTime).To(Equal(int64(123460000)))
+		Expect(kd.Kline.TakerBuyBaseAssetVolume.String()).To(Equal("500"))
+		Expect(kd.Kline.BaseAssetVolume.String()).To(Equal("1000"))
+	})
+
+	Context("Should walk a range of klines", func() {
+		const (
+			minute = int64(60000)
+			total  = 2500
+			// the first kline starts at 2020-09-13T12:40:00Z
+			first = int64(26666680) * minute
+		)
+		var (
+			ts      *httptest.Server
+			calls   int
+			limited int
+		)
+
+		BeforeEach(func() {
+			calls = 0
+			limited = 0
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				calls++
+				if limited > 0 {
+					limited--
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				Expect(r.URL.Query().Get("limit")).To(Equal("1000"))
+				start, _ := strconv.ParseInt(r.URL.Query().Get("startTime"), 10, 64)
+				// repeat the previous kline to overlap the pages like Binance can
+				if start > first {
+					start -= minute
+				}
+				rows := make([][]interface{}, 0, 1000)
+				for t := start - start%minute; t < first+total*minute && len(rows) < 1000; t += minute {
+					rows = append(rows, []interface{}{t, "1", "1", "1", "1", "1", t + minute - 1, "1", 1, "1", "1", "0"})
+				}
+				b, _ := json.Marshal(rows)
+				_, _ = w.Write(b)
+			}))
+		})
+
+		AfterEach(func() {
+			ts.Close()
+		})
+
+		It("should return every kline once", func() {
+			it := newAPI(ts.URL).KlineIterator(context.Background(), "ETHBTC", model.OneMinute, first, 0)
+			var n int64
+			for it.Next() {
+				Expect(it.Kline().StartTime).To(Equal(first + n*minute))
+				n++
+			}
+			Expect(it.Err()).To(BeNil())
+			Expect(n).To(Equal(int64(total)))
+			Expect(calls).To(Equal(3))
+		})
+
+		It("should request a page again after the rate limit ban", func() {
+			limited = 2
+			var n int
+			err := newAPI(ts.URL).WalkKlines(context.Background(), "ETHBTC", model.OneMinute, first, 0, func(k model.Kline) error {
+				n++
+				return nil
+			})
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(total))
+			Expect(calls).To(Equal(5))
+		})
+
+		It("should require a start time", func() {
+			it := newAPI(ts.URL).KlineIterator(context.Background(), "ETHBTC", model.OneMinute, 0, first+total*minute)
+			Expect(it.Next()).To(BeFalse())
+			Expect(it.Err()).To(Equal(binance.NoStartTimeProvided))
+			Expect(calls).To(BeZero())
+		})
+
+		It("should stop walking on an error of the callback", func() {
+			stop := errors.New("stop")
+			var n int
+			err := newAPI(ts.URL).WalkKlines(context.Background(), "ETHBTC", model.OneMinute, first, 0, func(k model.Kline) error {
+				n++
+				if n == 1500 {
+					return stop
+				}
+				return nil
+			})
+			Expect(err).To(Equal(stop))
+			Expect(calls).To(Equal(2))
+		})
+
+		It("should report the errors of the endpoint", func() {
+			it := newAPI(ts.URL).KlineIterator(context.Background(), "", model.OneMinute, first, 0)
+			Expect(it.Next()).To(BeFalse())
+			Expect(it.Err()).To(Equal(binance.NoSymbolProvided))
+		})
+	})
+})
@@ -1,9 +1,5 @@
 package model
 
-import (
-	"strconv"
-)
-
 // AccountUpdateType defines the messages that can be send via
 // the user data stream
 type AccountUpdateType string
@@ -20,6 +16,9 @@ const (
 
 	// ExecutionReportType shows updates on orders
 	ExecutionReportType AccountUpdateType = "executionReport"
+
+	// ListStatusType shows updates on order lists, like OCO orders
+	ListStatusType AccountUpdateType = "listStatus"
 )
 
 // UserAccountUpdate interface allows multiple typse of messages to be
@@ -47,11 +46,11 @@ func (oap OutboundAccountPosition) Type() AccountUpdateType {
 // BalanceUpdate shows deposits or withdrawals from the account and transfer
 // of funds between accounts
 type BalanceUpdate struct {
-	EventType    string `json:"e"`
-	EventTime    int64  `json:"E"`
-	Asset        string `json:"a"`
-	BalanceDelta string `json:"d"`
-	ClearTime    int64  `json:"T"`
+	EventType    string  `json:"e"`
+	EventTime    int64   `json:"E"`
+	Asset        string  `json:"a"`
+	BalanceDelta Decimal `json:"d"`
+	ClearTime    int64   `json:"T"`
 }
 
 // Type returns the type of message this account update is
@@ -61,36 +60,45 @@ func (bu BalanceUpdate) Type() AccountUpdateType {
 
 // ExecutionReport shows updates on orders
 type ExecutionReport struct {
-	EventType                string        `json:"e"`
-	EventTime                int64         `json:"E"`
-	Symbol                   string        `json:"s"`
-	ClientOrderID            string        `json:"c"`
-	Side                     OrderSide     `json:"S"`
-	OrderType                OrderType     `json:"o"`
-	TIF                      TimeInForce   `json:"f"`
-	OrderQuantity            string        `json:"q"`
-	OrderPrice               string        `json:"p"`
-	StopPrice                string        `json:"P"`
-	IcebergQuantity          string        `json:"F"`
-	OrderListID              int           `json:"g"`
-	OriginalClientOrderID    interface{}   `json:"C"`
-	CurrentExecutionType     ExecutionType `json:"x"`
-	CurrentOrderStatus       string        `json:"X"`
-	OrderRejectReason        string        `json:"r"`
-	OrderID                  int           `json:"i"`
-	LastExecutedQuantity     string        `json:"l"`
-	CumulativeFilledQuantity string        `json:"z"`
-	LastExecutedPrice        string        `json:"L"`
-	CommissionAmount         string        `json:"n"`
-	CommissionAsset          interface{}   `json:"N"`
-	TransactionTime          int64         `json:"T"`
-	TradeID                  int           `json:"t"`
-	OnOrderBook              bool          `json:"w"`
-	MakerSide                bool          `json:"m"`
-	OrderCreationTime        int64         `json:"O"`
-	CumulativeQuoteQuantity  string        `json:"Z"`
-	LastQuoteQuantity        string        `json:"Y"`
-	QuoteOrderQuantity       string        `json:"Q"`
+	EventType                string                  `json:"e"`
+	EventTime                int64                   `json:"E"`
+	Symbol                   string                  `json:"s"`
+	ClientOrderID            string                  `json:"c"`
+	Side                     OrderSide               `json:"S"`
+	OrderType                OrderType               `json:"o"`
+	TIF                      TimeInForce             `json:"f"`
+	OrderQuantity            Decimal                 `json:"q"`
+	OrderPrice               Decimal                 `json:"p"`
+	StopPrice                Decimal                 `json:"P"`
+	TrailingDelta            int64                   `json:"d"`
+	IcebergQuantity          Decimal                 `json:"F"`
+	OrderListID              int                     `json:"g"`
+	OriginalClientOrderID    interface{}             `json:"C"`
+	CurrentExecutionType     ExecutionType           `json:"x"`
+	CurrentOrderStatus       string                  `json:"X"`
+	OrderRejectReason        string                  `json:"r"`
+	OrderID                  int                     `json:"i"`
+	LastExecutedQuantity     Decimal                 `json:"l"`
+	CumulativeFilledQuantity Decimal                 `json:"z"`
+	LastExecutedPrice        Decimal                 `json:"L"`
+	CommissionAmount         Decimal                 `json:"n"`
+	CommissionAsset          interface{}             `json:"N"`
+	TransactionTime          int64                   `json:"T"`
+	TradeID                  int                     `json:"t"`
+	PreventedMatchID         int64                   `json:"v"`
+	ExecutionID              int64                   `json:"I"`
+	OnOrderBook              bool                    `json:"w"`
+	MakerSide                bool                    `json:"m"`
+	Ignore                   bool                    `json:"M"`
+	OrderCreationTime        int64                   `json:"O"`
+	CumulativeQuoteQuantity  Decimal                 `json:"Z"`
+	LastQuoteQuantity        Decimal                 `json:"Y"`
+	QuoteOrderQuantity       Decimal                 `json:"Q"`
+	TrailingTime             int64                   `json:"D"`
+	StrategyID               int64                   `json:"j"`
+	StrategyType             int64                   `json:"J"`
+	WorkingTime              int64                   `json:"W"`
+	SelfTradePreventionMode  SelfTradePreventionMode `json:"V"`
 }
 
 // Type returns the type of message this account update is
@@ -98,24 +106,43 @@ func (er ExecutionReport) Type() AccountUpdateType {
 	return ExecutionReportType
 }
 
+// ListStatusOrder identifies an order that is part of a list status update
+type ListStatusOrder struct {
+	Symbol        string `json:"s"`
+	OrderID       int    `json:"i"`
+	ClientOrderID string `json:"c"`
+}
+
+// ListStatus shows updates on order lists, like OCO orders
+type ListStatus struct {
+	EventType         string            `json:"e"`
+	EventTime         int64             `json:"E"`
+	Symbol            string            `json:"s"`
+	OrderListID       int               `json:"g"`
+	ContingencyType   string            `json:"c"`
+	ListStatusType    string            `json:"l"`
+	ListOrderStatus   string            `json:"L"`
+	ListRejectReason  string            `json:"r"`
+	ListClientOrderID string            `json:"C"`
+	TransactionTime   int64             `json:"T"`
+	Orders            []ListStatusOrder `json:"O"`
+}
+
+// Type returns the type of message this account update is
+func (ls ListStatus) Type() AccountUpdateType {
+	return ListStatusType
+}
+
 // Balance holds per symbol asset details
 type Balance struct {
-	Asset  string `json:"asset"`
-	Free   string `json:"free"`
-	Locked string `json:"locked"`
+	Asset  string  `json:"asset"`
+	Free   Decimal `json:"free"`
+	Locked Decimal `json:"locked"`
 }
 
 // Total of a asset
-func (b Balance) Total() float64 {
-	f, err := strconv.ParseFloat(b.Free, 32)
-	if err != nil {
-		panic(err)
-	}
-	l, err := strconv.ParseFloat(b.Locked, 32)
-	if err != nil {
-		panic(err)
-	}
-	return f + l
+func (b Balance) Total() Decimal {
+	return b.Free.Add(b.Locked)
 }
 
 // AccountInfo holds full list of account details
@@ -0,0 +1,255 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var bigTen = big.NewInt(10)
+
+// maxExponent bounds the exponent ParseDecimal accepts, so input like "1e999999999" can not
+// allocate huge coefficients
+const maxExponent = 64
+
+// Decimal is an arbitrary precision fixed-point number used for prices and quantities.
+// Unlike float64 it represents the decimal strings Binance sends without loss. The zero
+// value is 0 and a Decimal is never modified after creation, so it is safe to copy.
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// NewDecimal returns the Decimal value * 10^-scale
+func NewDecimal(value int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(value), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(value), scale: scale}
+}
+
+// NewDecimalFromFloat returns the Decimal with the shortest representation of the float.
+// NaN and infinite values return zero.
+func NewDecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// ParseDecimal parses a decimal string like "0.00150000", "-12" or "1e-8". Exponents are
+// limited to ±64.
+func ParseDecimal(s string) (Decimal, error) {
+	in := s
+	exp := 0
+	if n := strings.IndexAny(s, "eE"); n > -1 {
+		e, err := strconv.Atoi(s[n+1:])
+		if err != nil || e > maxExponent || e < -maxExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", in)
+		}
+		exp = e
+		s = s[:n]
+	}
+	scale := 0
+	if n := strings.IndexByte(s, '.'); n > -1 {
+		scale = len(s) - n - 1
+		s = s[:n] + s[n+1:]
+	}
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 || digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", in)
+	}
+	coef, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", in)
+	}
+	scale -= exp
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics when the string can not be parsed
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// align returns the coefficients of both decimals expressed in the same scale
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	switch {
+	case a.scale > b.scale:
+		return a.int(), new(big.Int).Mul(b.int(), pow10(a.scale-b.scale)), a.scale
+	case a.scale < b.scale:
+		return new(big.Int).Mul(a.int(), pow10(b.scale-a.scale)), b.int(), b.scale
+	}
+	return a.int(), b.int(), a.scale
+}
+
+// Add returns d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{coef: new(big.Int).Add(x, y), scale: scale}
+}
+
+// Sub returns d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{coef: new(big.Int).Sub(x, y), scale: scale}
+}
+
+// Mul returns d * o
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Div returns d / o truncated to the amount of decimals in scale. It panics when o is zero.
+func (d Decimal) Div(o Decimal, scale int32) Decimal {
+	num := new(big.Int).Set(d.int())
+	den := new(big.Int).Set(o.int())
+	if shift := scale - d.scale + o.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{coef: num.Quo(num, den), scale: scale}
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns the absolute value of d
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Truncate drops the decimals beyond scale, rounding towards zero
+func (d Decimal) Truncate(scale int32) Decimal {
+	if scale >= d.scale {
+		return d
+	}
+	return Decimal{coef: new(big.Int).Quo(d.int(), pow10(d.scale-scale)), scale: scale}
+}
+
+// Floor rounds d down to a multiple of step, d is returned when step is zero
+func (d Decimal) Floor(step Decimal) Decimal {
+	if step.IsZero() {
+		return d
+	}
+	r := d.Div(step, 0).Mul(step)
+	if r.Cmp(d) > 0 {
+		r = r.Sub(step.Abs())
+	}
+	return r
+}
+
+// Ceil rounds d up to a multiple of step, d is returned when step is zero
+func (d Decimal) Ceil(step Decimal) Decimal {
+	if step.IsZero() {
+		return d
+	}
+	r := d.Div(step, 0).Mul(step)
+	if r.Cmp(d) < 0 {
+		r = r.Add(step.Abs())
+	}
+	return r
+}
+
+// Cmp returns -1 when d < o, 0 when d == o and 1 when d > o
+func (d Decimal) Cmp(o Decimal) int {
+	x, y, _ := align(d, o)
+	return x.Cmp(y)
+}
+
+// Equal reports whether d and o represent the same number, regardless of their scale
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of d
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d equals zero
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Scale returns the amount of decimals d is expressed in
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Float64 returns the nearest float64 value of d
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d with all of its decimals, like Binance formats its numbers
+func (d Decimal) String() string {
+	if d.coef == nil {
+		return "0"
+	}
+	s := new(big.Int).Abs(d.coef).String()
+	sign := ""
+	if d.coef.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + s
+	}
+	if pad := int(d.scale) - len(s) + 1; pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	n := len(s) - int(d.scale)
+	return sign + s[:n] + "." + s[n:]
+}
+
+// MarshalJSON encodes d as a JSON string to prevent any loss of precision
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes both JSON strings and numbers, null and empty strings are decoded as zero
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" {
+		*d = Decimal{}
+		return nil
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
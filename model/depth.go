@@ -0,0 +1,110 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var (
+	two        = NewDecimal(2, 0)
+	oneHundred = NewDecimal(100, 0)
+)
+
+// PriceLevel is the total quantity on the order book at a price
+type PriceLevel struct {
+	Price Decimal
+	Qty   Decimal
+}
+
+// UnmarshalJSON decodes the [price, quantity] pairs Binance uses for price levels
+func (pl *PriceLevel) UnmarshalJSON(b []byte) error {
+	var pair []Decimal
+	if err := json.Unmarshal(b, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("price level holds %d instead of 2 values", len(pair))
+	}
+	pl.Price, pl.Qty = pair[0], pair[1]
+	return nil
+}
+
+// MarshalJSON encodes the price level as [price, quantity] pair
+func (pl PriceLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]Decimal{pl.Price, pl.Qty})
+}
+
+// BestBid returns the highest bid, false is returned when there are no bids
+func (o Orders) BestBid() (PriceLevel, bool) {
+	if len(o.Bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return o.Bids[0], true
+}
+
+// BestAsk returns the lowest ask, false is returned when there are no asks
+func (o Orders) BestAsk() (PriceLevel, bool) {
+	if len(o.Asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return o.Asks[0], true
+}
+
+// Spread returns the difference between the best ask and bid, false is returned when either
+// side is empty
+func (o Orders) Spread() (Decimal, bool) {
+	bid, okBid := o.BestBid()
+	ask, okAsk := o.BestAsk()
+	if !okBid || !okAsk {
+		return Decimal{}, false
+	}
+	return ask.Price.Sub(bid.Price), true
+}
+
+// Mid returns the price halfway the best ask and bid, false is returned when either side is empty
+func (o Orders) Mid() (Decimal, bool) {
+	bid, okBid := o.BestBid()
+	ask, okAsk := o.BestAsk()
+	if !okBid || !okAsk {
+		return Decimal{}, false
+	}
+	sum := bid.Price.Add(ask.Price)
+	// a single extra decimal keeps halving exact
+	return sum.Div(two, sum.Scale()+1), true
+}
+
+// VolumeWithin returns the quantity of the bids and asks priced within pct percent of the mid
+// price. Zero volumes are returned when either side is empty.
+func (o Orders) VolumeWithin(pct Decimal) (bids, asks Decimal) {
+	mid, ok := o.Mid()
+	if !ok {
+		return Decimal{}, Decimal{}
+	}
+	delta := mid.Mul(pct).Div(oneHundred, mid.Scale()+pct.Scale()+2)
+	low, high := mid.Sub(delta), mid.Add(delta)
+
+	for _, pl := range o.Bids {
+		if pl.Price.Cmp(low) < 0 {
+			break
+		}
+		bids = bids.Add(pl.Qty)
+	}
+	for _, pl := range o.Asks {
+		if pl.Price.Cmp(high) > 0 {
+			break
+		}
+		asks = asks.Add(pl.Qty)
+	}
+	return bids, asks
+}
+
+// DepthUpdate is an event of the diff depth stream, a zero quantity removes the price level
+type DepthUpdate struct {
+	EventType     string       `json:"e"`
+	EventTime     int64        `json:"E"`
+	Symbol        string       `json:"s"`
+	FirstUpdateID int64        `json:"U"`
+	FinalUpdateID int64        `json:"u"`
+	Bids          []PriceLevel `json:"b"`
+	Asks          []PriceLevel `json:"a"`
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
@@ -8,6 +9,8 @@ import (
 type Error struct {
 	Code int    `json:"code"`
 	Msg  string `json:"msg"`
+	// Data holds additional details some endpoints return, like the cancel-replace results
+	Data json.RawMessage `json:"data,omitempty"`
 }
 
 func (be Error) Error() string {
@@ -1,31 +1,142 @@
 package model
 
+import "time"
+
+// RateLimitType enumerates the kind of limits Binance enforces
+type RateLimitType string
+
+const (
+	// RequestWeight limits the sum of the weights of all requests
+	RequestWeight RateLimitType = "REQUEST_WEIGHT"
+	// OrdersLimit limits the amount of orders placed
+	OrdersLimit RateLimitType = "ORDERS"
+	// RawRequests limits the amount of requests regardless of their weight
+	RawRequests RateLimitType = "RAW_REQUESTS"
+)
+
+// RateLimitInterval is the unit of the window a rate limit applies to
+type RateLimitInterval string
+
+const (
+	// Second interval
+	Second RateLimitInterval = "SECOND"
+	// Minute interval
+	Minute RateLimitInterval = "MINUTE"
+	// Hour interval
+	Hour RateLimitInterval = "HOUR"
+	// Day interval
+	Day RateLimitInterval = "DAY"
+)
+
+// RateLimit describes a single limit enforced by Binance
+type RateLimit struct {
+	RateLimitType RateLimitType     `json:"rateLimitType"`
+	Interval      RateLimitInterval `json:"interval"`
+	IntervalNum   int               `json:"intervalNum"`
+	Limit         int               `json:"limit"`
+}
+
+// Duration of the window this rate limit applies to
+func (rl RateLimit) Duration() time.Duration {
+	var d time.Duration
+	switch rl.Interval {
+	case Second:
+		d = time.Second
+	case Minute:
+		d = time.Minute
+	case Hour:
+		d = time.Hour
+	case Day:
+		d = 24 * time.Hour
+	}
+	return d * time.Duration(rl.IntervalNum)
+}
+
 // SymbolInfo provides data about a symbol
 type SymbolInfo struct {
-	Symbol                     string        `json:"symbol"`
-	Status                     string        `json:"status"`
-	BaseAsset                  string        `json:"baseAsset"`
-	BaseAssetPrecision         int           `json:"baseAssetPrecision"`
-	QuoteAsset                 string        `json:"quoteAsset"`
-	QuotePrecision             int           `json:"quotePrecision"`
-	QuoteAssetPrecision        int           `json:"quoteAssetPrecision"`
-	BaseCommissionPrecision    int           `json:"baseCommissionPrecision"`
-	QuoteCommissionPrecision   int           `json:"quoteCommissionPrecision"`
-	OrderTypes                 []string      `json:"orderTypes"`
-	IcebergAllowed             bool          `json:"icebergAllowed"`
-	OcoAllowed                 bool          `json:"ocoAllowed"`
-	QuoteOrderQtyMarketAllowed bool          `json:"quoteOrderQtyMarketAllowed"`
-	IsSpotTradingAllowed       bool          `json:"isSpotTradingAllowed"`
-	IsMarginTradingAllowed     bool          `json:"isMarginTradingAllowed"`
-	Filters                    []interface{} `json:"filters"`
-	Permissions                []string      `json:"permissions"`
+	Symbol                     string   `json:"symbol"`
+	Status                     string   `json:"status"`
+	BaseAsset                  string   `json:"baseAsset"`
+	BaseAssetPrecision         int      `json:"baseAssetPrecision"`
+	QuoteAsset                 string   `json:"quoteAsset"`
+	QuotePrecision             int      `json:"quotePrecision"`
+	QuoteAssetPrecision        int      `json:"quoteAssetPrecision"`
+	BaseCommissionPrecision    int      `json:"baseCommissionPrecision"`
+	QuoteCommissionPrecision   int      `json:"quoteCommissionPrecision"`
+	OrderTypes                 []string `json:"orderTypes"`
+	IcebergAllowed             bool     `json:"icebergAllowed"`
+	OcoAllowed                 bool     `json:"ocoAllowed"`
+	QuoteOrderQtyMarketAllowed bool     `json:"quoteOrderQtyMarketAllowed"`
+	IsSpotTradingAllowed       bool     `json:"isSpotTradingAllowed"`
+	IsMarginTradingAllowed     bool     `json:"isMarginTradingAllowed"`
+	Filters                    Filters  `json:"filters"`
+	Permissions                []string `json:"permissions"`
 }
 
 // ExchangeInfo holds data about the exchange and internals
 type ExchangeInfo struct {
-	Timezone        string        `json:"timezone"`
-	ServerTime      int64         `json:"serverTime"`
-	RateLimits      []interface{} `json:"rateLimits"`
-	ExchangeFilters []interface{} `json:"exchangeFilters"`
-	Symbols         []SymbolInfo  `json:"symbols"`
+	Timezone        string       `json:"timezone"`
+	ServerTime      int64        `json:"serverTime"`
+	RateLimits      []RateLimit  `json:"rateLimits"`
+	ExchangeFilters Filters      `json:"exchangeFilters"`
+	Symbols         []SymbolInfo `json:"symbols"`
+}
+
+// RoundPrice snaps price to the tickSize of the PRICE_FILTER. Buying prices are rounded down
+// and selling prices up, so the rounding never works against the order.
+func (si SymbolInfo) RoundPrice(price Decimal, side OrderSide) Decimal {
+	f, ok := si.Filters.Find(PriceFilterType).(PriceFilter)
+	if !ok {
+		return price
+	}
+	return roundStep(price, f.MinPrice, f.TickSize, side == Buy)
+}
+
+// RoundQty rounds qty down to the stepSize of the LOT_SIZE filter, so it never exceeds the
+// amount that was intended or available
+func (si SymbolInfo) RoundQty(qty Decimal) Decimal {
+	f, ok := si.Filters.Find(LotSizeType).(LotSize)
+	if !ok {
+		return qty
+	}
+	return roundStep(qty, f.MinQty, f.StepSize, true)
+}
+
+// MinQtyForNotional returns the smallest quantity on the stepSize of the LOT_SIZE filter
+// satisfying both its minQty and the minimum notional of the NOTIONAL or MIN_NOTIONAL filter
+// at the given price
+func (si SymbolInfo) MinQtyForNotional(price Decimal) Decimal {
+	var minQty, step, minNotional Decimal
+	if f, ok := si.Filters.Find(LotSizeType).(LotSize); ok {
+		minQty, step = f.MinQty, f.StepSize
+	}
+	if f, ok := si.Filters.Find(NotionalType).(Notional); ok {
+		minNotional = f.MinNotional
+	} else if f, ok := si.Filters.Find(MinNotionalType).(MinNotional); ok {
+		minNotional = f.MinNotional
+	}
+
+	qty := minQty
+	if !minNotional.IsZero() && price.Sign() > 0 {
+		// the extra decimals keep the truncation of the division within a single step
+		q := minNotional.Div(price, step.Scale()+price.Scale()+1)
+		for q.Mul(price).Cmp(minNotional) < 0 {
+			q = q.Add(NewDecimal(1, q.Scale()))
+		}
+		if q.Cmp(qty) > 0 {
+			qty = q
+		}
+	}
+	return roundStep(qty, minQty, step, false)
+}
+
+// roundStep snaps v to a multiple of step counted from min, as Binance validates the filters
+func roundStep(v, min, step Decimal, down bool) Decimal {
+	if step.IsZero() {
+		return v
+	}
+	if down {
+		return v.Sub(min).Floor(step).Add(min)
+	}
+	return v.Sub(min).Ceil(step).Add(min)
 }
@@ -0,0 +1,353 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FilterType enumerates the symbol and exchange filters Binance enforces on orders
+type FilterType string
+
+const (
+	// PriceFilterType defines the price rules of a symbol
+	PriceFilterType FilterType = "PRICE_FILTER"
+	// PercentPriceType defines the valid range of the price based on the average price
+	PercentPriceType FilterType = "PERCENT_PRICE"
+	// PercentPriceBySideType defines the valid range of the price per side based on the average price
+	PercentPriceBySideType FilterType = "PERCENT_PRICE_BY_SIDE"
+	// LotSizeType defines the quantity rules of a symbol
+	LotSizeType FilterType = "LOT_SIZE"
+	// MinNotionalType defines the minimum notional value of an order
+	MinNotionalType FilterType = "MIN_NOTIONAL"
+	// NotionalType defines the range of the notional value of an order
+	NotionalType FilterType = "NOTIONAL"
+	// IcebergPartsType defines the maximum parts an iceberg order can have
+	IcebergPartsType FilterType = "ICEBERG_PARTS"
+	// MarketLotSizeType defines the quantity rules of MARKET orders
+	MarketLotSizeType FilterType = "MARKET_LOT_SIZE"
+	// MaxNumOrdersType defines the maximum open orders on a symbol
+	MaxNumOrdersType FilterType = "MAX_NUM_ORDERS"
+	// MaxNumAlgoOrdersType defines the maximum open stop orders on a symbol
+	MaxNumAlgoOrdersType FilterType = "MAX_NUM_ALGO_ORDERS"
+	// MaxNumIcebergOrdersType defines the maximum open iceberg orders on a symbol
+	MaxNumIcebergOrdersType FilterType = "MAX_NUM_ICEBERG_ORDERS"
+	// MaxPositionType defines the maximum position an account can hold on the base asset
+	MaxPositionType FilterType = "MAX_POSITION"
+	// TrailingDeltaType defines the valid range of the trailing delta
+	TrailingDeltaType FilterType = "TRAILING_DELTA"
+	// ExchangeMaxNumOrdersType defines the maximum open orders on the exchange
+	ExchangeMaxNumOrdersType FilterType = "EXCHANGE_MAX_NUM_ORDERS"
+	// ExchangeMaxNumAlgoOrdersType defines the maximum open stop orders on the exchange
+	ExchangeMaxNumAlgoOrdersType FilterType = "EXCHANGE_MAX_NUM_ALGO_ORDERS"
+	// ExchangeMaxNumIcebergOrdersType defines the maximum open iceberg orders on the exchange
+	ExchangeMaxNumIcebergOrdersType FilterType = "EXCHANGE_MAX_NUM_ICEBERG_ORDERS"
+)
+
+// Filter is implemented by all filter types so they can be bundled in a single list
+type Filter interface {
+	// Type returns the type of filter
+	Type() FilterType
+}
+
+// PriceFilter defines the price rules of a symbol, a zero value disables the rule
+type PriceFilter struct {
+	MinPrice Decimal `json:"minPrice"`
+	MaxPrice Decimal `json:"maxPrice"`
+	TickSize Decimal `json:"tickSize"`
+}
+
+// Type returns the type of filter
+func (PriceFilter) Type() FilterType { return PriceFilterType }
+
+// PercentPrice defines the valid range of the price based on the average price
+type PercentPrice struct {
+	MultiplierUp   Decimal `json:"multiplierUp"`
+	MultiplierDown Decimal `json:"multiplierDown"`
+	AvgPriceMins   int     `json:"avgPriceMins"`
+}
+
+// Type returns the type of filter
+func (PercentPrice) Type() FilterType { return PercentPriceType }
+
+// PercentPriceBySide defines the valid range of the price per side based on the average price
+type PercentPriceBySide struct {
+	BidMultiplierUp   Decimal `json:"bidMultiplierUp"`
+	BidMultiplierDown Decimal `json:"bidMultiplierDown"`
+	AskMultiplierUp   Decimal `json:"askMultiplierUp"`
+	AskMultiplierDown Decimal `json:"askMultiplierDown"`
+	AvgPriceMins      int     `json:"avgPriceMins"`
+}
+
+// Type returns the type of filter
+func (PercentPriceBySide) Type() FilterType { return PercentPriceBySideType }
+
+// LotSize defines the quantity rules of a symbol
+type LotSize struct {
+	MinQty   Decimal `json:"minQty"`
+	MaxQty   Decimal `json:"maxQty"`
+	StepSize Decimal `json:"stepSize"`
+}
+
+// Type returns the type of filter
+func (LotSize) Type() FilterType { return LotSizeType }
+
+// MinNotional defines the minimum notional value (price * quantity) of an order
+type MinNotional struct {
+	MinNotional   Decimal `json:"minNotional"`
+	ApplyToMarket bool    `json:"applyToMarket"`
+	AvgPriceMins  int     `json:"avgPriceMins"`
+}
+
+// Type returns the type of filter
+func (MinNotional) Type() FilterType { return MinNotionalType }
+
+// Notional defines the range of the notional value (price * quantity) of an order
+type Notional struct {
+	MinNotional      Decimal `json:"minNotional"`
+	ApplyMinToMarket bool    `json:"applyMinToMarket"`
+	MaxNotional      Decimal `json:"maxNotional"`
+	ApplyMaxToMarket bool    `json:"applyMaxToMarket"`
+	AvgPriceMins     int     `json:"avgPriceMins"`
+}
+
+// Type returns the type of filter
+func (Notional) Type() FilterType { return NotionalType }
+
+// IcebergParts defines the maximum parts an iceberg order can have
+type IcebergParts struct {
+	Limit int `json:"limit"`
+}
+
+// Type returns the type of filter
+func (IcebergParts) Type() FilterType { return IcebergPartsType }
+
+// MarketLotSize defines the quantity rules of MARKET orders
+type MarketLotSize struct {
+	MinQty   Decimal `json:"minQty"`
+	MaxQty   Decimal `json:"maxQty"`
+	StepSize Decimal `json:"stepSize"`
+}
+
+// Type returns the type of filter
+func (MarketLotSize) Type() FilterType { return MarketLotSizeType }
+
+// MaxNumOrders defines the maximum open orders on a symbol
+type MaxNumOrders struct {
+	MaxNumOrders int `json:"maxNumOrders"`
+}
+
+// Type returns the type of filter
+func (MaxNumOrders) Type() FilterType { return MaxNumOrdersType }
+
+// MaxNumAlgoOrders defines the maximum open stop orders on a symbol
+type MaxNumAlgoOrders struct {
+	MaxNumAlgoOrders int `json:"maxNumAlgoOrders"`
+}
+
+// Type returns the type of filter
+func (MaxNumAlgoOrders) Type() FilterType { return MaxNumAlgoOrdersType }
+
+// MaxNumIcebergOrders defines the maximum open iceberg orders on a symbol
+type MaxNumIcebergOrders struct {
+	MaxNumIcebergOrders int `json:"maxNumIcebergOrders"`
+}
+
+// Type returns the type of filter
+func (MaxNumIcebergOrders) Type() FilterType { return MaxNumIcebergOrdersType }
+
+// MaxPosition defines the maximum position an account can hold on the base asset
+type MaxPosition struct {
+	MaxPosition Decimal `json:"maxPosition"`
+}
+
+// Type returns the type of filter
+func (MaxPosition) Type() FilterType { return MaxPositionType }
+
+// TrailingDelta defines the valid range of the trailing delta in BIPS
+type TrailingDelta struct {
+	MinTrailingAboveDelta int64 `json:"minTrailingAboveDelta"`
+	MaxTrailingAboveDelta int64 `json:"maxTrailingAboveDelta"`
+	MinTrailingBelowDelta int64 `json:"minTrailingBelowDelta"`
+	MaxTrailingBelowDelta int64 `json:"maxTrailingBelowDelta"`
+}
+
+// Type returns the type of filter
+func (TrailingDelta) Type() FilterType { return TrailingDeltaType }
+
+// ExchangeMaxNumOrders defines the maximum open orders on the exchange
+type ExchangeMaxNumOrders struct {
+	MaxNumOrders int `json:"maxNumOrders"`
+}
+
+// Type returns the type of filter
+func (ExchangeMaxNumOrders) Type() FilterType { return ExchangeMaxNumOrdersType }
+
+// ExchangeMaxNumAlgoOrders defines the maximum open stop orders on the exchange
+type ExchangeMaxNumAlgoOrders struct {
+	MaxNumAlgoOrders int `json:"maxNumAlgoOrders"`
+}
+
+// Type returns the type of filter
+func (ExchangeMaxNumAlgoOrders) Type() FilterType { return ExchangeMaxNumAlgoOrdersType }
+
+// ExchangeMaxNumIcebergOrders defines the maximum open iceberg orders on the exchange
+type ExchangeMaxNumIcebergOrders struct {
+	MaxNumIcebergOrders int `json:"maxNumIcebergOrders"`
+}
+
+// Type returns the type of filter
+func (ExchangeMaxNumIcebergOrders) Type() FilterType { return ExchangeMaxNumIcebergOrdersType }
+
+// UnknownFilter holds a filter this library does not know (yet)
+type UnknownFilter struct {
+	FilterType FilterType
+	Raw        json.RawMessage
+}
+
+// Type returns the type of filter
+func (uf UnknownFilter) Type() FilterType { return uf.FilterType }
+
+// Filters is a list of filters decoded into their typed structures
+type Filters []Filter
+
+// Find returns the first filter of the type, or nil when not present
+func (fs Filters) Find(t FilterType) Filter {
+	for _, f := range fs {
+		if f.Type() == t {
+			return f
+		}
+	}
+	return nil
+}
+
+func newFilter(t FilterType) Filter {
+	switch t {
+	case PriceFilterType:
+		return &PriceFilter{}
+	case PercentPriceType:
+		return &PercentPrice{}
+	case PercentPriceBySideType:
+		return &PercentPriceBySide{}
+	case LotSizeType:
+		return &LotSize{}
+	case MinNotionalType:
+		return &MinNotional{}
+	case NotionalType:
+		return &Notional{}
+	case IcebergPartsType:
+		return &IcebergParts{}
+	case MarketLotSizeType:
+		return &MarketLotSize{}
+	case MaxNumOrdersType:
+		return &MaxNumOrders{}
+	case MaxNumAlgoOrdersType:
+		return &MaxNumAlgoOrders{}
+	case MaxNumIcebergOrdersType:
+		return &MaxNumIcebergOrders{}
+	case MaxPositionType:
+		return &MaxPosition{}
+	case TrailingDeltaType:
+		return &TrailingDelta{}
+	case ExchangeMaxNumOrdersType:
+		return &ExchangeMaxNumOrders{}
+	case ExchangeMaxNumAlgoOrdersType:
+		return &ExchangeMaxNumAlgoOrders{}
+	case ExchangeMaxNumIcebergOrdersType:
+		return &ExchangeMaxNumIcebergOrders{}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes every filter into the structure matching its filterType, the
+// filters are stored as values
+func (fs *Filters) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	out := make(Filters, 0, len(raw))
+	for _, r := range raw {
+		var head struct {
+			FilterType FilterType `json:"filterType"`
+		}
+		if err := json.Unmarshal(r, &head); err != nil {
+			return err
+		}
+		f := newFilter(head.FilterType)
+		if f == nil {
+			out = append(out, UnknownFilter{FilterType: head.FilterType, Raw: r})
+			continue
+		}
+		if err := json.Unmarshal(r, f); err != nil {
+			return fmt.Errorf("decoding filter %s: %w", head.FilterType, err)
+		}
+		// dereference so callers can type assert on the value types
+		out = append(out, derefFilter(f))
+	}
+	*fs = out
+	return nil
+}
+
+// MarshalJSON encodes the filters including their filterType
+func (fs Filters) MarshalJSON() ([]byte, error) {
+	out := make([]json.RawMessage, 0, len(fs))
+	for _, f := range fs {
+		if uf, ok := f.(UnknownFilter); ok {
+			out = append(out, uf.Raw)
+			continue
+		}
+		b, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(b, &m); err != nil {
+			return nil, err
+		}
+		m["filterType"], _ = json.Marshal(f.Type())
+		b, err = json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return json.Marshal(out)
+}
+
+func derefFilter(f Filter) Filter {
+	switch v := f.(type) {
+	case *PriceFilter:
+		return *v
+	case *PercentPrice:
+		return *v
+	case *PercentPriceBySide:
+		return *v
+	case *LotSize:
+		return *v
+	case *MinNotional:
+		return *v
+	case *Notional:
+		return *v
+	case *IcebergParts:
+		return *v
+	case *MarketLotSize:
+		return *v
+	case *MaxNumOrders:
+		return *v
+	case *MaxNumAlgoOrders:
+		return *v
+	case *MaxNumIcebergOrders:
+		return *v
+	case *MaxPosition:
+		return *v
+	case *TrailingDelta:
+		return *v
+	case *ExchangeMaxNumOrders:
+		return *v
+	case *ExchangeMaxNumAlgoOrders:
+		return *v
+	case *ExchangeMaxNumIcebergOrders:
+		return *v
+	}
+	return f
+}
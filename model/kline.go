@@ -1,23 +1,103 @@
 package model
 
-// Kline /Candlestick data for a symbol
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// KlineInterval enumerates the intervals klines are available in
+type KlineInterval string
+
+const (
+	// OneSecond kline interval
+	OneSecond KlineInterval = "1s"
+	// OneMinute kline interval
+	OneMinute KlineInterval = "1m"
+	// ThreeMinutes kline interval
+	ThreeMinutes KlineInterval = "3m"
+	// FiveMinutes kline interval
+	FiveMinutes KlineInterval = "5m"
+	// FifteenMinutes kline interval
+	FifteenMinutes KlineInterval = "15m"
+	// ThirtyMinutes kline interval
+	ThirtyMinutes KlineInterval = "30m"
+	// OneHour kline interval
+	OneHour KlineInterval = "1h"
+	// TwoHours kline interval
+	TwoHours KlineInterval = "2h"
+	// FourHours kline interval
+	FourHours KlineInterval = "4h"
+	// SixHours kline interval
+	SixHours KlineInterval = "6h"
+	// EightHours kline interval
+	EightHours KlineInterval = "8h"
+	// TwelveHours kline interval
+	TwelveHours KlineInterval = "12h"
+	// OneDay kline interval
+	OneDay KlineInterval = "1d"
+	// ThreeDays kline interval
+	ThreeDays KlineInterval = "3d"
+	// OneWeek kline interval
+	OneWeek KlineInterval = "1w"
+	// OneMonth kline interval
+	OneMonth KlineInterval = "1M"
+)
+
+// Kline /Candlestick data for a symbol. The REST endpoints do not report Symbol, Interval,
+// the trade ids and Closed in their rows, only the streams do.
 type Kline struct {
-	StartTime                int64  `json:"t"`
-	CloseTime                int64  `json:"T"`
-	Symbol                   string `json:"s"`
-	Interval                 string `json:"i"`
-	FirstTradeID             int    `json:"f"`
-	LastTradeID              int    `json:"L"`
-	OpenPrice                string `json:"o"`
-	ClosePrice               string `json:"c"`
-	HighPrice                string `json:"h"`
-	LowPrice                 string `json:"l"`
-	BaseAssetVolume          string `json:"v"`
-	NumberOfTrades           int    `json:"n"`
-	Closed                   bool   `json:"x"`
-	QuoteAssetVolume         string `json:"q"`
-	TakerBuyBaseAssetVolume  string `json:"V"`
-	TakerBuyQuoteAssetVolume string `json:"Q"`
+	StartTime                int64         `json:"t"`
+	CloseTime                int64         `json:"T"`
+	Symbol                   string        `json:"s"`
+	Interval                 KlineInterval `json:"i"`
+	FirstTradeID             int           `json:"f"`
+	LastTradeID              int           `json:"L"`
+	OpenPrice                Decimal       `json:"o"`
+	ClosePrice               Decimal       `json:"c"`
+	HighPrice                Decimal       `json:"h"`
+	LowPrice                 Decimal       `json:"l"`
+	BaseAssetVolume          Decimal       `json:"v"`
+	NumberOfTrades           int           `json:"n"`
+	Closed                   bool          `json:"x"`
+	QuoteAssetVolume         Decimal       `json:"q"`
+	TakerBuyBaseAssetVolume  Decimal       `json:"V"`
+	TakerBuyQuoteAssetVolume Decimal       `json:"Q"`
+}
+
+// UnmarshalJSON decodes both the stream objects and the positional rows of the REST endpoints
+func (k *Kline) UnmarshalJSON(b []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		type kline Kline
+		return json.Unmarshal(b, (*kline)(k))
+	}
+
+	var row []json.RawMessage
+	if err := json.Unmarshal(b, &row); err != nil {
+		return err
+	}
+	if len(row) < 11 {
+		return fmt.Errorf("kline row holds %d instead of at least 11 fields", len(row))
+	}
+	fields := []interface{}{
+		&k.StartTime,
+		&k.OpenPrice,
+		&k.HighPrice,
+		&k.LowPrice,
+		&k.ClosePrice,
+		&k.BaseAssetVolume,
+		&k.CloseTime,
+		&k.QuoteAssetVolume,
+		&k.NumberOfTrades,
+		&k.TakerBuyBaseAssetVolume,
+		&k.TakerBuyQuoteAssetVolume,
+	}
+	for i, f := range fields {
+		if err := json.Unmarshal(row[i], f); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // KlineData wrapper for a symbol
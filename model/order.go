@@ -54,6 +54,21 @@ const (
 	FillOrKill TimeInForce = "FOK"
 )
 
+// SelfTradePreventionMode defines what happens when an order would trade against
+// an order of the same account
+type SelfTradePreventionMode string
+
+const (
+	// ExpireTaker expires the taker order
+	ExpireTaker SelfTradePreventionMode = "EXPIRE_TAKER"
+	// ExpireMaker expires the maker order
+	ExpireMaker SelfTradePreventionMode = "EXPIRE_MAKER"
+	// ExpireBoth expires both the taker and maker order
+	ExpireBoth SelfTradePreventionMode = "EXPIRE_BOTH"
+	// NoPrevention allows orders to trade against each other
+	NoPrevention SelfTradePreventionMode = "NONE"
+)
+
 // ExecutionType enumerates different execution types an order can have
 type ExecutionType string
 
@@ -76,11 +91,45 @@ const (
 	Expired ExecutionType = "EXPIRED"
 )
 
-// Orders holds information from the depth endpoint of the Binance API
+// CancelReplaceMode defines how a cancel-replace continues when the cancel fails
+type CancelReplaceMode string
+
+const (
+	// StopOnFailure does not place the new order when the cancel fails
+	StopOnFailure CancelReplaceMode = "STOP_ON_FAILURE"
+	// AllowFailure places the new order even when the cancel fails
+	AllowFailure CancelReplaceMode = "ALLOW_FAILURE"
+)
+
+// CancelReplaceResult reflects the outcome of one of the steps of a cancel-replace
+type CancelReplaceResult string
+
+const (
+	// Success the step succeeded
+	Success CancelReplaceResult = "SUCCESS"
+	// Failure the step failed
+	Failure CancelReplaceResult = "FAILURE"
+	// NotAttempted the step was skipped because an earlier step failed
+	NotAttempted CancelReplaceResult = "NOT_ATTEMPTED"
+)
+
+// CancelReplaceResponse holds the outcome of both the cancel and the new order of a
+// cancel-replace. For a failed step the error is set instead of the response.
+type CancelReplaceResponse struct {
+	CancelResult     CancelReplaceResult
+	NewOrderResult   CancelReplaceResult
+	CancelResponse   *CancelOrderResponse
+	CancelError      *Error
+	NewOrderResponse OrderResponse
+	NewOrderError    *Error
+}
+
+// Orders holds information from the depth endpoint of the Binance API. The bids are sorted
+// from the highest and the asks from the lowest price.
 type Orders struct {
-	LastUpdateID int        `json:"lastUpdateId"`
-	Bids         [][]string `json:"bids"`
-	Asks         [][]string `json:"asks"`
+	LastUpdateID int          `json:"lastUpdateId"`
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
 }
 
 // OrderResponse interface exposes the fields all order response types
@@ -100,24 +149,24 @@ type OrderResponse interface {
 
 // UserOrder holds data about a single order from the user order book
 type UserOrder struct {
-	Symbol              string `json:"symbol"`
-	OrderID             int    `json:"orderId"`
-	OrderListID         int    `json:"orderListId"`
-	ClientOrderID       string `json:"clientOrderId"`
-	Price               string `json:"price"`
-	OrigQty             string `json:"origQty"`
-	ExecutedQty         string `json:"executedQty"`
-	CummulativeQuoteQty string `json:"cummulativeQuoteQty"`
-	Status              string `json:"status"`
-	TimeInForce         string `json:"timeInForce"`
-	Type                string `json:"type"`
-	Side                string `json:"side"`
-	StopPrice           string `json:"stopPrice"`
-	IcebergQty          string `json:"icebergQty"`
-	Time                int64  `json:"time"`
-	UpdateTime          int64  `json:"updateTime"`
-	IsWorking           bool   `json:"isWorking"`
-	OrigQuoteOrderQty   string `json:"origQuoteOrderQty"`
+	Symbol              string  `json:"symbol"`
+	OrderID             int     `json:"orderId"`
+	OrderListID         int     `json:"orderListId"`
+	ClientOrderID       string  `json:"clientOrderId"`
+	Price               Decimal `json:"price"`
+	OrigQty             Decimal `json:"origQty"`
+	ExecutedQty         Decimal `json:"executedQty"`
+	CummulativeQuoteQty Decimal `json:"cummulativeQuoteQty"`
+	Status              string  `json:"status"`
+	TimeInForce         string  `json:"timeInForce"`
+	Type                string  `json:"type"`
+	Side                string  `json:"side"`
+	StopPrice           Decimal `json:"stopPrice"`
+	IcebergQty          Decimal `json:"icebergQty"`
+	Time                int64   `json:"time"`
+	UpdateTime          int64   `json:"updateTime"`
+	IsWorking           bool    `json:"isWorking"`
+	OrigQuoteOrderQty   Decimal `json:"origQuoteOrderQty"`
 }
 
 // OrderResponseAck holds the fields for the ACK order response
@@ -146,19 +195,28 @@ func (r OrderResponseAck) TransactionTime() int64 { return r.TransactTime }
 
 // OrderResponseResult holds the fields for the RESPONSE order response
 type OrderResponseResult struct {
-	Sym                 string `json:"symbol"`
-	Order               int    `json:"orderId"`
-	OrderList           int    `json:"orderListId"`
-	ClientOrder         string `json:"clientOrderId"`
-	TransactTime        int64  `json:"transactTime"`
-	Price               string `json:"price"`
-	OrigQty             string `json:"origQty"`
-	ExecutedQty         string `json:"executedQty"`
-	CummulativeQuoteQty string `json:"cummulativeQuoteQty"`
-	Status              string `json:"status"`
-	TimeInForce         string `json:"timeInForce"`
-	Type                string `json:"type"`
-	Side                string `json:"side"`
+	Sym                 string  `json:"symbol"`
+	Order               int     `json:"orderId"`
+	OrderList           int     `json:"orderListId"`
+	ClientOrder         string  `json:"clientOrderId"`
+	TransactTime        int64   `json:"transactTime"`
+	Price               Decimal `json:"price"`
+	OrigQty             Decimal `json:"origQty"`
+	ExecutedQty         Decimal `json:"executedQty"`
+	CummulativeQuoteQty Decimal `json:"cummulativeQuoteQty"`
+	Status              string  `json:"status"`
+	TimeInForce         string  `json:"timeInForce"`
+	Type                string  `json:"type"`
+	Side                string  `json:"side"`
+	StopPrice           Decimal `json:"stopPrice"`
+	IcebergQty          Decimal `json:"icebergQty"`
+	WorkingTime         int64   `json:"workingTime"`
+	StrategyID          int64   `json:"strategyId"`
+	StrategyType        int64   `json:"strategyType"`
+	TrailingDelta       int64   `json:"trailingDelta"`
+	TrailingTime        int64   `json:"trailingTime"`
+
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
 }
 
 // Symbol returns the symbol for this order
@@ -178,28 +236,37 @@ func (r OrderResponseResult) TransactionTime() int64 { return r.TransactTime }
 
 // Fill holds details about how a order was filled
 type Fill struct {
-	Price           string `json:"price"`
-	Qty             string `json:"qty"`
-	Commission      string `json:"commission"`
-	CommissionAsset string `json:"commissionAsset"`
+	Price           Decimal `json:"price"`
+	Qty             Decimal `json:"qty"`
+	Commission      Decimal `json:"commission"`
+	CommissionAsset string  `json:"commissionAsset"`
 }
 
 // OrderResponseFull holds the fields for the full order response type
 type OrderResponseFull struct {
-	Sym                 string `json:"symbol"`
-	Order               int    `json:"orderId"`
-	OrderList           int    `json:"orderListId"`
-	ClientOrder         string `json:"clientOrderId"`
-	TransactTime        int64  `json:"transactTime"`
-	Price               string `json:"price"`
-	OrigQty             string `json:"origQty"`
-	ExecutedQty         string `json:"executedQty"`
-	CummulativeQuoteQty string `json:"cummulativeQuoteQty"`
-	Status              string `json:"status"`
-	TimeInForce         string `json:"timeInForce"`
-	Type                string `json:"type"`
-	Side                string `json:"side"`
-	Fills               []Fill `json:"fills"`
+	Sym                 string  `json:"symbol"`
+	Order               int     `json:"orderId"`
+	OrderList           int     `json:"orderListId"`
+	ClientOrder         string  `json:"clientOrderId"`
+	TransactTime        int64   `json:"transactTime"`
+	Price               Decimal `json:"price"`
+	OrigQty             Decimal `json:"origQty"`
+	ExecutedQty         Decimal `json:"executedQty"`
+	CummulativeQuoteQty Decimal `json:"cummulativeQuoteQty"`
+	Status              string  `json:"status"`
+	TimeInForce         string  `json:"timeInForce"`
+	Type                string  `json:"type"`
+	Side                string  `json:"side"`
+	StopPrice           Decimal `json:"stopPrice"`
+	IcebergQty          Decimal `json:"icebergQty"`
+	WorkingTime         int64   `json:"workingTime"`
+	StrategyID          int64   `json:"strategyId"`
+	StrategyType        int64   `json:"strategyType"`
+	TrailingDelta       int64   `json:"trailingDelta"`
+	TrailingTime        int64   `json:"trailingTime"`
+	Fills               []Fill  `json:"fills"`
+
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
 }
 
 // Symbol returns the symbol for this order
@@ -216,3 +283,21 @@ func (r OrderResponseFull) ClientOrderID() string { return r.ClientOrder }
 
 // TransactionTime of the order
 func (r OrderResponseFull) TransactionTime() int64 { return r.TransactTime }
+
+// CancelOrderResponse holds the details of a canceled order
+type CancelOrderResponse struct {
+	Symbol              string      `json:"symbol"`
+	OrigClientOrderID   string      `json:"origClientOrderId"`
+	OrderID             int         `json:"orderId"`
+	OrderListID         int         `json:"orderListId"`
+	ClientOrderID       string      `json:"clientOrderId"`
+	TransactTime        int64       `json:"transactTime"`
+	Price               Decimal     `json:"price"`
+	OrigQty             Decimal     `json:"origQty"`
+	ExecutedQty         Decimal     `json:"executedQty"`
+	CummulativeQuoteQty Decimal     `json:"cummulativeQuoteQty"`
+	Status              string      `json:"status"`
+	TimeInForce         TimeInForce `json:"timeInForce"`
+	Type                OrderType   `json:"type"`
+	Side                OrderSide   `json:"side"`
+}
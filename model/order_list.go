@@ -0,0 +1,41 @@
+package model
+
+// OrderListOrder identifies an order that is part of an order list
+type OrderListOrder struct {
+	Symbol        string `json:"symbol"`
+	OrderID       int    `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+}
+
+// OrderReport holds the details of an order that is part of an order list
+type OrderReport struct {
+	Symbol              string      `json:"symbol"`
+	OrigClientOrderID   string      `json:"origClientOrderId"`
+	OrderID             int         `json:"orderId"`
+	OrderListID         int         `json:"orderListId"`
+	ClientOrderID       string      `json:"clientOrderId"`
+	TransactTime        int64       `json:"transactTime"`
+	Price               Decimal     `json:"price"`
+	OrigQty             Decimal     `json:"origQty"`
+	ExecutedQty         Decimal     `json:"executedQty"`
+	CummulativeQuoteQty Decimal     `json:"cummulativeQuoteQty"`
+	Status              string      `json:"status"`
+	TimeInForce         TimeInForce `json:"timeInForce"`
+	Type                OrderType   `json:"type"`
+	Side                OrderSide   `json:"side"`
+	StopPrice           Decimal     `json:"stopPrice"`
+	IcebergQty          Decimal     `json:"icebergQty"`
+}
+
+// OrderList holds data about a list of contingent orders, like an OCO
+type OrderList struct {
+	OrderListID       int              `json:"orderListId"`
+	ContingencyType   string           `json:"contingencyType"`
+	ListStatusType    string           `json:"listStatusType"`
+	ListOrderStatus   string           `json:"listOrderStatus"`
+	ListClientOrderID string           `json:"listClientOrderId"`
+	TransactionTime   int64            `json:"transactionTime"`
+	Symbol            string           `json:"symbol"`
+	Orders            []OrderListOrder `json:"orders"`
+	OrderReports      []OrderReport    `json:"orderReports"`
+}
@@ -0,0 +1,12 @@
+package model
+
+// SystemStatus reports whether Binance is in maintenance
+type SystemStatus struct {
+	Status int    `json:"status"`
+	Msg    string `json:"msg"`
+}
+
+// Maintenance reports whether the system is in maintenance
+func (ss SystemStatus) Maintenance() bool {
+	return ss.Status == 1
+}
@@ -2,50 +2,60 @@ package model
 
 // Ticker represents raw ticker data
 type Ticker struct {
-	MessageType            string `json:"e"`
-	EventTime              int64  `json:"E"`
-	Symbol                 string `json:"s"`
-	PriceChange            string `json:"p"`
-	PriceChangePercent     string `json:"P"`
-	WeightedAveragePrice   string `json:"w"`
-	FirstPrice             string `json:"x"`
-	LastPrice              string `json:"c"`
-	LastQuantity           string `json:"Q"`
-	BestBidPrice           string `json:"b"`
-	BestBidQuantity        string `json:"B"`
-	BestAskPrice           string `json:"a"`
-	BestAskQuantity        string `json:"A"`
-	OpenPrice              string `json:"o"`
-	HighPrice              string `json:"h"`
-	LowPrice               string `json:"l"`
-	TotalTradedBaseVolume  string `json:"v"`
-	TotalTradedQuoteVolume string `json:"q"`
-	StatisticsOpenTime     int64  `json:"O"`
-	StatisticsCloseTime    int64  `json:"C"`
-	FirstTradeID           int    `json:"F"`
-	LastTradeID            int    `json:"L"`
-	NumberOfTrades         int    `json:"n"`
+	MessageType            string  `json:"e"`
+	EventTime              int64   `json:"E"`
+	Symbol                 string  `json:"s"`
+	PriceChange            Decimal `json:"p"`
+	PriceChangePercent     Decimal `json:"P"`
+	WeightedAveragePrice   Decimal `json:"w"`
+	FirstPrice             Decimal `json:"x"`
+	LastPrice              Decimal `json:"c"`
+	LastQuantity           Decimal `json:"Q"`
+	BestBidPrice           Decimal `json:"b"`
+	BestBidQuantity        Decimal `json:"B"`
+	BestAskPrice           Decimal `json:"a"`
+	BestAskQuantity        Decimal `json:"A"`
+	OpenPrice              Decimal `json:"o"`
+	HighPrice              Decimal `json:"h"`
+	LowPrice               Decimal `json:"l"`
+	TotalTradedBaseVolume  Decimal `json:"v"`
+	TotalTradedQuoteVolume Decimal `json:"q"`
+	StatisticsOpenTime     int64   `json:"O"`
+	StatisticsCloseTime    int64   `json:"C"`
+	FirstTradeID           int     `json:"F"`
+	LastTradeID            int     `json:"L"`
+	NumberOfTrades         int     `json:"n"`
 }
 
-// TickerStatistics hold data about the ticker data for a symbol
+// TickerStatistics hold data about the ticker data for a symbol. The rolling window ticker
+// does not report PrevClosePrice, LastQty, BidPrice and AskPrice
 type TickerStatistics struct {
-	Symbol             string `json:"symbol"`
-	PriceChange        string `json:"priceChange"`
-	PriceChangePercent string `json:"priceChangePercent"`
-	WeightedAvgPrice   string `json:"weightedAvgPrice"`
-	PrevClosePrice     string `json:"prevClosePrice"`
-	LastPrice          string `json:"lastPrice"`
-	LastQty            string `json:"lastQty"`
-	BidPrice           string `json:"bidPrice"`
-	AskPrice           string `json:"askPrice"`
-	OpenPrice          string `json:"openPrice"`
-	HighPrice          string `json:"highPrice"`
-	LowPrice           string `json:"lowPrice"`
-	Volume             string `json:"volume"`
-	QuoteVolume        string `json:"quoteVolume"`
-	OpenTime           int64  `json:"openTime"`
-	CloseTime          int64  `json:"closeTime"`
-	FirstID            int    `json:"firstId"`
-	LastID             int    `json:"lastId"`
-	Count              int    `json:"count"`
+	Symbol             string  `json:"symbol"`
+	PriceChange        Decimal `json:"priceChange"`
+	PriceChangePercent Decimal `json:"priceChangePercent"`
+	WeightedAvgPrice   Decimal `json:"weightedAvgPrice"`
+	PrevClosePrice     Decimal `json:"prevClosePrice"`
+	LastPrice          Decimal `json:"lastPrice"`
+	LastQty            Decimal `json:"lastQty"`
+	BidPrice           Decimal `json:"bidPrice"`
+	AskPrice           Decimal `json:"askPrice"`
+	OpenPrice          Decimal `json:"openPrice"`
+	HighPrice          Decimal `json:"highPrice"`
+	LowPrice           Decimal `json:"lowPrice"`
+	Volume             Decimal `json:"volume"`
+	QuoteVolume        Decimal `json:"quoteVolume"`
+	OpenTime           int64   `json:"openTime"`
+	CloseTime          int64   `json:"closeTime"`
+	FirstID            int     `json:"firstId"`
+	LastID             int     `json:"lastId"`
+	Count              int     `json:"count"`
+}
+
+// BookTicker holds the best price and quantity on the order book of a symbol
+type BookTicker struct {
+	Symbol   string  `json:"symbol"`
+	BidPrice Decimal `json:"bidPrice"`
+	BidQty   Decimal `json:"bidQty"`
+	AskPrice Decimal `json:"askPrice"`
+	AskQty   Decimal `json:"askQty"`
 }
@@ -0,0 +1,6 @@
+package model
+
+// ServerTime holds the current time of the Binance servers in milliseconds
+type ServerTime struct {
+	ServerTime int64 `json:"serverTime"`
+}
@@ -2,17 +2,41 @@ package model
 
 // UserTrade shows the data about trade that has been performed
 type UserTrade struct {
-	Symbol          string `json:"symbol"`
-	ID              int    `json:"id"`
-	OrderID         int    `json:"orderId"`
-	OrderListID     int    `json:"orderListId"`
-	Price           string `json:"price"`
-	Qty             string `json:"qty"`
-	QuoteQty        string `json:"quoteQty"`
-	Commission      string `json:"commission"`
-	CommissionAsset string `json:"commissionAsset"`
-	Time            int64  `json:"time"`
-	IsBuyer         bool   `json:"isBuyer"`
-	IsMaker         bool   `json:"isMaker"`
-	IsBestMatch     bool   `json:"isBestMatch"`
+	Symbol          string  `json:"symbol"`
+	ID              int     `json:"id"`
+	OrderID         int     `json:"orderId"`
+	OrderListID     int     `json:"orderListId"`
+	Price           Decimal `json:"price"`
+	Qty             Decimal `json:"qty"`
+	QuoteQty        Decimal `json:"quoteQty"`
+	Commission      Decimal `json:"commission"`
+	CommissionAsset string  `json:"commissionAsset"`
+	Time            int64   `json:"time"`
+	IsBuyer         bool    `json:"isBuyer"`
+	IsMaker         bool    `json:"isMaker"`
+	IsBestMatch     bool    `json:"isBestMatch"`
+}
+
+// MarketTrade is a public trade on a symbol
+type MarketTrade struct {
+	ID           int64   `json:"id"`
+	Price        Decimal `json:"price"`
+	Qty          Decimal `json:"qty"`
+	QuoteQty     Decimal `json:"quoteQty"`
+	Time         int64   `json:"time"`
+	IsBuyerMaker bool    `json:"isBuyerMaker"`
+	IsBestMatch  bool    `json:"isBestMatch"`
+}
+
+// AggTrade holds the trades filled at the same time, from the same order and at the same
+// price as a single aggregate trade
+type AggTrade struct {
+	ID           int64   `json:"a"`
+	Price        Decimal `json:"p"`
+	Qty          Decimal `json:"q"`
+	FirstTradeID int64   `json:"f"`
+	LastTradeID  int64   `json:"l"`
+	Time         int64   `json:"T"`
+	IsBuyerMaker bool    `json:"m"`
+	IsBestMatch  bool    `json:"M"`
 }
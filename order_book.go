@@ -0,0 +1,280 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+// defaultSnapshotLimit is the depth of the snapshot an order book starts from
+const defaultSnapshotLimit = 1000
+
+// OrderBookParams configures a locally maintained order book
+type OrderBookParams struct {
+	// Fast follows the 100ms diff depth stream instead of the 1000ms one
+	Fast bool
+	// SnapshotLimit is the depth of the snapshot the book starts from, defaults to 1000
+	SnapshotLimit int
+}
+
+// OrderBook is an order book of a symbol kept up to date with the diff depth stream. It
+// follows the procedure Binance describes: the updates are buffered while a depth snapshot
+// is fetched, stale updates are dropped and the book is synchronised again from a new
+// snapshot when an update is missed. It is safe for concurrent use.
+type OrderBook struct {
+	symbol string
+
+	mut          sync.RWMutex
+	bids         []model.PriceLevel
+	asks         []model.PriceLevel
+	lastUpdateID int64
+	synced       bool
+	changes      []chan struct{}
+	stopped      bool
+}
+
+func (s *streamer) OrderBook(ctx context.Context, symbol string, params OrderBookParams) (*OrderBook, error) {
+	if symbol == "" {
+		return nil, NoSymbolProvided
+	}
+	limit := params.SnapshotLimit
+	if limit == 0 {
+		limit = defaultSnapshotLimit
+	}
+	if err := checkDepthLimit(limit); err != nil {
+		return nil, err
+	}
+	name := strings.ToLower(symbol) + "@depth"
+	if params.Fast {
+		name += "@100ms"
+	}
+
+	reads, err := s.Subscribe(ctx, []string{name})
+	if err != nil {
+		return nil, err
+	}
+
+	ob := &OrderBook{symbol: symbol}
+	go ob.run(ctx, s, name, reads, limit)
+
+	return ob, nil
+}
+
+// Symbol the book holds the orders of
+func (ob *OrderBook) Symbol() string {
+	return ob.symbol
+}
+
+// Synced reports whether the book is up to date. While the book synchronises, and after the
+// context of the book is done, it is false and the levels of the book can not be relied upon.
+func (ob *OrderBook) Synced() bool {
+	ob.mut.RLock()
+	defer ob.mut.RUnlock()
+	return ob.synced
+}
+
+// Snapshot returns a copy of the best n levels of both sides, all levels when n is zero
+func (ob *OrderBook) Snapshot(n int) model.Orders {
+	ob.mut.RLock()
+	defer ob.mut.RUnlock()
+	return model.Orders{
+		LastUpdateID: int(ob.lastUpdateID),
+		Bids:         topLevels(ob.bids, n),
+		Asks:         topLevels(ob.asks, n),
+	}
+}
+
+// Changes returns a channel receiving a signal after the book changed. Signals are merged
+// while the receiver is busy, so a receiver only misses intermediate states. The channel is
+// closed when the context of the book is done.
+func (ob *OrderBook) Changes() <-chan struct{} {
+	ob.mut.Lock()
+	defer ob.mut.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if ob.stopped {
+		close(ch)
+		return ch
+	}
+	ob.changes = append(ob.changes, ch)
+	return ch
+}
+
+func (ob *OrderBook) run(ctx context.Context, s *streamer, name string, reads <-chan model.StreamData, limit int) {
+	defer func() {
+		ob.stop()
+		// only drop the subscription of this book, other subscribers of the symbol keep theirs
+		if err := s.removeSubscriber(name, reads); err != nil {
+			_ = s.logger.Log("method", "OrderBook", "unsubscribe", "error", err.Error())
+		}
+	}()
+
+	var (
+		pending   []model.DepthUpdate
+		snapshots = make(chan model.Orders, 1)
+		// loaded is set while the book holds a snapshot the pending updates can be applied to
+		loaded bool
+	)
+	// fetching a snapshot is done aside, so the updates keep being buffered meanwhile
+	fetch := func() {
+		go func() {
+			for {
+				o, err := s.api.DepthContext(WithWaitOnRateLimit(ctx), ob.symbol, limit)
+				if err == nil {
+					snapshots <- o
+					return
+				}
+				_ = s.logger.Log("method", "OrderBook", "snapshot", "error", err.Error())
+				if wait(ctx, time.Second) != nil {
+					return
+				}
+			}
+		}()
+	}
+	// apply the pending updates, a new snapshot is fetched when one is missing
+	drain := func() {
+		for i, du := range pending {
+			if !ob.apply(du) {
+				loaded = false
+				_ = s.logger.Log("method", "OrderBook", "resync", ob.symbol)
+				pending = append(pending[:0], pending[i:]...)
+				fetch()
+				return
+			}
+		}
+		pending = pending[:0]
+	}
+
+	fetch()
+	for {
+		select {
+		case msg, ok := <-reads:
+			if !ok {
+				return
+			}
+			var du model.DepthUpdate
+			if err := json.Unmarshal(msg.Data, &du); err != nil {
+				_ = s.logger.Log("method", "OrderBook", "error", err.Error())
+				continue
+			}
+			pending = append(pending, du)
+			if loaded {
+				drain()
+			}
+		case o := <-snapshots:
+			ob.reset(o)
+			loaded = true
+			drain()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reset replaces the book with the snapshot. The book is synced once the first update
+// following the snapshot is applied.
+func (ob *OrderBook) reset(o model.Orders) {
+	ob.mut.Lock()
+	defer ob.mut.Unlock()
+	ob.bids = o.Bids
+	ob.asks = o.Asks
+	ob.lastUpdateID = int64(o.LastUpdateID)
+	ob.synced = false
+}
+
+// apply the update to the book. When an update is missing the book is emptied and false
+// is returned.
+func (ob *OrderBook) apply(du model.DepthUpdate) bool {
+	ob.mut.Lock()
+	// already part of the book
+	if du.FinalUpdateID <= ob.lastUpdateID {
+		ob.mut.Unlock()
+		return true
+	}
+	if du.FirstUpdateID > ob.lastUpdateID+1 {
+		wasSynced := ob.synced
+		ob.bids, ob.asks = nil, nil
+		ob.lastUpdateID = 0
+		ob.synced = false
+		ob.mut.Unlock()
+
+		if wasSynced {
+			ob.notify()
+		}
+		return false
+	}
+	for _, pl := range du.Bids {
+		ob.bids = updateLevel(ob.bids, pl, true)
+	}
+	for _, pl := range du.Asks {
+		ob.asks = updateLevel(ob.asks, pl, false)
+	}
+	ob.lastUpdateID = du.FinalUpdateID
+	ob.synced = true
+	ob.mut.Unlock()
+
+	ob.notify()
+	return true
+}
+
+func (ob *OrderBook) notify() {
+	ob.mut.RLock()
+	defer ob.mut.RUnlock()
+	for _, ch := range ob.changes {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (ob *OrderBook) stop() {
+	ob.mut.Lock()
+	defer ob.mut.Unlock()
+	ob.stopped = true
+	ob.synced = false
+	for _, ch := range ob.changes {
+		close(ch)
+	}
+	ob.changes = nil
+}
+
+// updateLevel sets, or removes on a zero quantity, the price level in a side sorted from
+// the best price
+func updateLevel(side []model.PriceLevel, pl model.PriceLevel, bids bool) []model.PriceLevel {
+	i := sort.Search(len(side), func(i int) bool {
+		if bids {
+			return side[i].Price.Cmp(pl.Price) <= 0
+		}
+		return side[i].Price.Cmp(pl.Price) >= 0
+	})
+	found := i < len(side) && side[i].Price.Equal(pl.Price)
+
+	switch {
+	case pl.Qty.IsZero() && found:
+		return append(side[:i], side[i+1:]...)
+	case pl.Qty.IsZero():
+		return side
+	case found:
+		side[i] = pl
+		return side
+	}
+	side = append(side, model.PriceLevel{})
+	copy(side[i+1:], side[i:])
+	side[i] = pl
+	return side
+}
+
+func topLevels(side []model.PriceLevel, n int) []model.PriceLevel {
+	if n <= 0 || n > len(side) {
+		n = len(side)
+	}
+	out := make([]model.PriceLevel, n)
+	copy(out, side[:n])
+	return out
+}
@@ -0,0 +1,314 @@
+package binance_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance"
+	"github.com/jaztec/go-binance/model"
+)
+
+// depthServer serves the depth snapshots in order and pushes the diff events after subscribing
+// to the depth stream, events sent on push are written as they come. When release is set
+// every snapshot waits for a signal on it.
+type depthServer struct {
+	mut       sync.Mutex
+	snapshots []string
+	events    []string
+	push      chan string
+	release   chan struct{}
+	calls     int
+	messages  []binance.SubscribeMessage
+}
+
+func (s *depthServer) depthCalls() int {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.calls
+}
+
+func (s *depthServer) received() []binance.SubscribeMessage {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return append([]binance.SubscribeMessage(nil), s.messages...)
+}
+
+func (s *depthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	if r.URL.Path == "/api/v3/depth" {
+		s.mut.Lock()
+		n := s.calls
+		if n >= len(s.snapshots) {
+			n = len(s.snapshots) - 1
+		}
+		snapshot := s.snapshots[n]
+		s.calls++
+		s.mut.Unlock()
+		if s.release != nil {
+			select {
+			case <-s.release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		_, _ = w.Write([]byte(snapshot))
+		return
+	}
+
+	c, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	Expect(err).To(BeNil())
+	defer c.Close()
+
+	subscribed := make(chan struct{}, 1)
+	left := make(chan struct{})
+	go func() {
+		defer GinkgoRecover()
+		defer close(left)
+		// keep the connection open until the client leaves
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var sub binance.SubscribeMessage
+			Expect(json.Unmarshal(msg, &sub)).To(Succeed())
+			s.mut.Lock()
+			s.messages = append(s.messages, sub)
+			s.mut.Unlock()
+
+			if sub.Method == binance.Subscribe && sub.Params[0] == "bnbbtc@depth@100ms" {
+				subscribed <- struct{}{}
+			}
+		}
+	}()
+
+	write := func(e string) {
+		data := fmt.Sprintf(`{"stream":"bnbbtc@depth@100ms","data":%s}`, e)
+		Expect(c.WriteMessage(websocket.TextMessage, []byte(data))).To(Succeed())
+	}
+	for {
+		select {
+		case <-subscribed:
+			for _, e := range s.events {
+				write(e)
+			}
+		case e := <-s.push:
+			write(e)
+		case <-left:
+			return
+		}
+	}
+}
+
+func (s *depthServer) unsubscribed() []string {
+	var params []string
+	for _, msg := range s.received() {
+		if msg.Method == binance.Unsubscribe {
+			params = append(params, msg.Params...)
+		}
+	}
+	return params
+}
+
+func depthEvent(first, final int64, bids, asks string) string {
+	return fmt.Sprintf(`{"e":"depthUpdate","E":1,"s":"BNBBTC","U":%d,"u":%d,"b":%s,"a":%s}`, first, final, bids, asks)
+}
+
+var _ = Describe("OrderBook", func() {
+	var (
+		ds       *depthServer
+		ts       *httptest.Server
+		ctx      context.Context
+		cancelFn context.CancelFunc
+	)
+
+	newClient := func() binance.APICaller {
+		ts = httptest.NewServer(ds)
+		a, err := binance.NewAPICaller(binance.APIConfig{
+			BaseURI:       ts.URL,
+			BaseStreamURI: strings.ReplaceAll(ts.URL, "http", "ws"),
+		})
+		Expect(err).To(BeNil())
+		return a
+	}
+
+	start := func() *binance.OrderBook {
+		ob, err := newClient().StreamCaller().OrderBook(ctx, "BNBBTC", binance.OrderBookParams{Fast: true})
+		Expect(err).To(BeNil())
+		return ob
+	}
+
+	BeforeEach(func() {
+		ctx, cancelFn = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancelFn()
+		ts.Close()
+	})
+
+	It("should apply the updates following the snapshot", func() {
+		ds = &depthServer{
+			snapshots: []string{
+				// too old for the first buffered update, so it is fetched again
+				`{"lastUpdateId":90,"bids":[["4.00","1"]],"asks":[["4.10","1"]]}`,
+				`{"lastUpdateId":100,"bids":[["4.00","1"],["3.90","2"]],"asks":[["4.10","1"]]}`,
+			},
+			events: []string{
+				depthEvent(95, 100, `[["4.00","9"]]`, `[]`),
+				depthEvent(99, 102, `[["4.05","3"],["3.90","0"]]`, `[["4.20","5"]]`),
+				depthEvent(103, 103, `[]`, `[["4.10","0"]]`),
+			},
+		}
+		ob := start()
+		changes := ob.Changes()
+
+		Eventually(func() int {
+			return ob.Snapshot(0).LastUpdateID
+		}, time.Second).Should(Equal(103))
+		Expect(ob.Synced()).To(BeTrue())
+		Expect(changes).To(Receive())
+		Expect(ds.depthCalls()).To(Equal(2))
+
+		o := ob.Snapshot(0)
+		Expect(o.Bids).To(Equal([]model.PriceLevel{
+			{Price: model.MustParseDecimal("4.05"), Qty: model.MustParseDecimal("3")},
+			{Price: model.MustParseDecimal("4.00"), Qty: model.MustParseDecimal("1")},
+		}))
+		Expect(o.Asks).To(Equal([]model.PriceLevel{
+			{Price: model.MustParseDecimal("4.20"), Qty: model.MustParseDecimal("5")},
+		}))
+		Expect(ob.Snapshot(1).Bids).To(HaveLen(1))
+	})
+
+	It("should not report a stale snapshot as synced", func() {
+		ds = &depthServer{
+			release: make(chan struct{}),
+			snapshots: []string{
+				`{"lastUpdateId":90,"bids":[["4.00","1"]],"asks":[["4.10","1"]]}`,
+				`{"lastUpdateId":100,"bids":[["4.00","1"]],"asks":[["4.10","1"]]}`,
+			},
+			events: []string{
+				depthEvent(95, 100, `[["4.00","9"]]`, `[]`),
+				depthEvent(101, 101, `[["4.00","2"]]`, `[]`),
+			},
+		}
+		ob := start()
+		changes := ob.Changes()
+
+		// the stale snapshot is loaded and a new one is requested
+		ds.release <- struct{}{}
+		Eventually(ds.depthCalls, time.Second).Should(Equal(2))
+		Consistently(changes, 100*time.Millisecond).ShouldNot(Receive())
+		Expect(ob.Synced()).To(BeFalse())
+
+		ds.release <- struct{}{}
+		Eventually(changes, time.Second).Should(Receive())
+		Expect(ob.Synced()).To(BeTrue())
+		Expect(ob.Snapshot(0).LastUpdateID).To(Equal(101))
+	})
+
+	It("should synchronise again when an update is missed", func() {
+		ds = &depthServer{
+			snapshots: []string{
+				`{"lastUpdateId":100,"bids":[["4.00","1"]],"asks":[["4.10","1"]]}`,
+				`{"lastUpdateId":110,"bids":[["4.00","7"]],"asks":[["4.10","7"]]}`,
+			},
+			events: []string{
+				depthEvent(101, 101, `[["4.00","2"]]`, `[]`),
+				// 102 up to 104 are missing
+				depthEvent(105, 110, `[["4.00","6"]]`, `[]`),
+				depthEvent(111, 111, `[]`, `[["4.10","8"]]`),
+			},
+		}
+		ob := start()
+
+		Eventually(func() int {
+			return ob.Snapshot(0).LastUpdateID
+		}, time.Second).Should(Equal(111))
+		Expect(ds.depthCalls()).To(Equal(2))
+		o := ob.Snapshot(0)
+		Expect(o.Bids[0].Qty.String()).To(Equal("7"))
+		Expect(o.Asks[0].Qty.String()).To(Equal("8"))
+	})
+
+	It("should close the changes when the context is done", func() {
+		ds = &depthServer{snapshots: []string{`{"lastUpdateId":1,"bids":[],"asks":[]}`}}
+		ob := start()
+		changes := ob.Changes()
+		cancelFn()
+		Eventually(changes, time.Second).Should(BeClosed())
+		Expect(ob.Synced()).To(BeFalse())
+	})
+
+	It("should keep the subscription of other books on the symbol", func() {
+		ds = &depthServer{
+			snapshots: []string{`{"lastUpdateId":100,"bids":[["4.00","1"]],"asks":[["4.10","1"]]}`},
+			push:      make(chan string),
+		}
+		a := newClient()
+		// the stream outlives the books
+		_, err := a.StreamCaller().Subscribe(ctx, []string{"bnbbtc@trade"})
+		Expect(err).To(BeNil())
+
+		firstCtx, firstCancelFn := context.WithCancel(ctx)
+		first, err := a.StreamCaller().OrderBook(firstCtx, "BNBBTC", binance.OrderBookParams{Fast: true})
+		Expect(err).To(BeNil())
+		secondCtx, secondCancelFn := context.WithCancel(ctx)
+		defer secondCancelFn()
+		second, err := a.StreamCaller().OrderBook(secondCtx, "BNBBTC", binance.OrderBookParams{Fast: true})
+		Expect(err).To(BeNil())
+
+		ds.push <- depthEvent(101, 101, `[["4.00","2"]]`, `[]`)
+		Eventually(first.Synced, time.Second).Should(BeTrue())
+		Eventually(second.Synced, time.Second).Should(BeTrue())
+
+		changes := first.Changes()
+		firstCancelFn()
+		Eventually(changes, time.Second).Should(BeClosed())
+
+		// the stream keeps delivering to the remaining book
+		for id := int64(102); id < 110; id++ {
+			ds.push <- depthEvent(id, id, `[["4.00","3"]]`, `[]`)
+		}
+		Eventually(func() int {
+			return second.Snapshot(0).LastUpdateID
+		}, time.Second).Should(Equal(109))
+		Expect(second.Synced()).To(BeTrue())
+		Expect(ds.unsubscribed()).To(BeEmpty())
+
+		secondCancelFn()
+		Eventually(ds.unsubscribed, time.Second).Should(Equal([]string{"bnbbtc@depth@100ms"}))
+	})
+
+	It("should unsubscribe from the depth stream when the context is done", func() {
+		ds = &depthServer{snapshots: []string{`{"lastUpdateId":1,"bids":[],"asks":[]}`}}
+		a := newClient()
+		// the stream outlives the book
+		_, err := a.StreamCaller().Subscribe(ctx, []string{"bnbbtc@trade"})
+		Expect(err).To(BeNil())
+
+		bookCtx, bookCancelFn := context.WithCancel(ctx)
+		ob, err := a.StreamCaller().OrderBook(bookCtx, "BNBBTC", binance.OrderBookParams{Fast: true})
+		Expect(err).To(BeNil())
+		changes := ob.Changes()
+		bookCancelFn()
+		Eventually(changes, time.Second).Should(BeClosed())
+
+		Eventually(ds.received, time.Second).Should(ContainElement(binance.SubscribeMessage{
+			Method: binance.Unsubscribe,
+			Params: []string{"bnbbtc@depth@100ms"},
+			ID:     3,
+		}))
+	})
+})
@@ -0,0 +1,191 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+// validateOrder checks the order against the symbol information when ValidateOrders is enabled
+func (a *api) validateOrder(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) error {
+	if !a.cfg.ValidateOrders {
+		return nil
+	}
+	si, err := a.SymbolContext(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	return validateOrder(si, side, orderType, params)
+}
+
+// roundOrderParams snaps the prices and quantities to the filters of the symbol when
+// RoundToFilters is set
+func (a *api) roundOrderParams(ctx context.Context, symbol string, side model.OrderSide, params OrderParams) (OrderParams, error) {
+	if !params.RoundToFilters {
+		return params, nil
+	}
+	si, err := a.SymbolContext(ctx, symbol)
+	if err != nil {
+		return params, err
+	}
+	if !params.Price.IsZero() {
+		params.Price = si.RoundPrice(params.Price, side)
+	}
+	if !params.StopPrice.IsZero() {
+		params.StopPrice = si.RoundPrice(params.StopPrice, side)
+	}
+	if !params.Quantity.IsZero() {
+		params.Quantity = si.RoundQty(params.Quantity)
+	}
+	if !params.IcebergQty.IsZero() {
+		params.IcebergQty = si.RoundQty(params.IcebergQty)
+	}
+	return params, nil
+}
+
+// validateOrder checks an order against the filters of a symbol. Filters depending on market data
+// (PERCENT_PRICE) or account state (MAX_NUM_ORDERS, MAX_POSITION) are left to Binance, as are the
+// price dependent filters on MARKET orders.
+func validateOrder(si model.SymbolInfo, side model.OrderSide, orderType model.OrderType, params OrderParams) error {
+	if si.Status != "" && si.Status != "TRADING" {
+		return FilterError{Symbol: si.Symbol, Reason: fmt.Sprintf("symbol is not trading, status is %s", si.Status)}
+	}
+	if len(si.OrderTypes) > 0 && !containsString(si.OrderTypes, string(orderType)) {
+		return FilterError{Symbol: si.Symbol, Reason: fmt.Sprintf("order type %s is not allowed", orderType)}
+	}
+	if !params.IcebergQty.IsZero() && !si.IcebergAllowed {
+		return FilterError{Symbol: si.Symbol, Reason: "iceberg orders are not allowed"}
+	}
+
+	for _, f := range si.Filters {
+		var reason string
+		switch f := f.(type) {
+		case model.PriceFilter:
+			reason = checkPriceFilter(f, params)
+		case model.LotSize:
+			reason = checkLotSize(f.MinQty, f.MaxQty, f.StepSize, params)
+		case model.MarketLotSize:
+			if orderType == model.Market {
+				reason = checkLotSize(f.MinQty, f.MaxQty, f.StepSize, params)
+			}
+		case model.MinNotional:
+			if n, ok := notional(orderType, params); ok && (orderType != model.Market || f.ApplyToMarket) {
+				reason = checkRange("notional", n, f.MinNotional, model.Decimal{}, model.Decimal{})
+			}
+		case model.Notional:
+			if n, ok := notional(orderType, params); ok {
+				min, max := f.MinNotional, f.MaxNotional
+				if orderType == model.Market && !f.ApplyMinToMarket {
+					min = model.Decimal{}
+				}
+				if orderType == model.Market && !f.ApplyMaxToMarket {
+					max = model.Decimal{}
+				}
+				reason = checkRange("notional", n, min, max, model.Decimal{})
+			}
+		case model.IcebergParts:
+			reason = checkIcebergParts(f, params)
+		case model.TrailingDelta:
+			reason = checkTrailingDelta(f, side, orderType, params)
+		}
+		if reason != "" {
+			return FilterError{Symbol: si.Symbol, Filter: f.Type(), Reason: reason}
+		}
+	}
+	return nil
+}
+
+func checkPriceFilter(f model.PriceFilter, params OrderParams) string {
+	if !params.Price.IsZero() {
+		if reason := checkRange("price", params.Price, f.MinPrice, f.MaxPrice, f.TickSize); reason != "" {
+			return reason
+		}
+	}
+	if !params.StopPrice.IsZero() {
+		return checkRange("stopPrice", params.StopPrice, f.MinPrice, f.MaxPrice, f.TickSize)
+	}
+	return ""
+}
+
+func checkLotSize(min, max, step model.Decimal, params OrderParams) string {
+	if !params.Quantity.IsZero() {
+		if reason := checkRange("quantity", params.Quantity, min, max, step); reason != "" {
+			return reason
+		}
+	}
+	if !params.IcebergQty.IsZero() {
+		return checkRange("icebergQty", params.IcebergQty, min, max, step)
+	}
+	return ""
+}
+
+func checkIcebergParts(f model.IcebergParts, params OrderParams) string {
+	if params.IcebergQty.IsZero() || params.Quantity.IsZero() || f.Limit == 0 {
+		return ""
+	}
+	parts := params.Quantity.Div(params.IcebergQty, 0)
+	if parts.Mul(params.IcebergQty).Cmp(params.Quantity) < 0 {
+		parts = parts.Add(model.NewDecimal(1, 0))
+	}
+	if parts.Cmp(model.NewDecimal(int64(f.Limit), 0)) > 0 {
+		return fmt.Sprintf("iceberg order would have %s parts, the maximum is %d", parts, f.Limit)
+	}
+	return ""
+}
+
+func checkTrailingDelta(f model.TrailingDelta, side model.OrderSide, orderType model.OrderType, params OrderParams) string {
+	if params.TrailingDelta == 0 {
+		return ""
+	}
+	min, max := f.MinTrailingBelowDelta, f.MaxTrailingBelowDelta
+	stopLoss := orderType == model.StopLoss || orderType == model.StopLossLimit
+	if (stopLoss && side == model.Buy) || (!stopLoss && side == model.Sell) {
+		min, max = f.MinTrailingAboveDelta, f.MaxTrailingAboveDelta
+	}
+	if min != 0 && params.TrailingDelta < min {
+		return fmt.Sprintf("trailingDelta %d is below the minimum of %d", params.TrailingDelta, min)
+	}
+	if max != 0 && params.TrailingDelta > max {
+		return fmt.Sprintf("trailingDelta %d is above the maximum of %d", params.TrailingDelta, max)
+	}
+	return ""
+}
+
+// notional returns the value of the order in the quote asset, when it is known upfront
+func notional(orderType model.OrderType, params OrderParams) (model.Decimal, bool) {
+	if orderType == model.Market && !params.QuoteOrderQty.IsZero() {
+		return params.QuoteOrderQty, true
+	}
+	if params.Price.IsZero() || params.Quantity.IsZero() {
+		return model.Decimal{}, false
+	}
+	return params.Price.Mul(params.Quantity), true
+}
+
+// checkRange returns why v is not within min and max or not on a step from min, zero values
+// disable the check
+func checkRange(name string, v, min, max, step model.Decimal) string {
+	if !min.IsZero() && v.Cmp(min) < 0 {
+		return fmt.Sprintf("%s %s is below the minimum of %s", name, v, min)
+	}
+	if !max.IsZero() && v.Cmp(max) > 0 {
+		return fmt.Sprintf("%s %s is above the maximum of %s", name, v, max)
+	}
+	if !step.IsZero() {
+		diff := v.Sub(min)
+		if !diff.Div(step, 0).Mul(step).Equal(diff) {
+			return fmt.Sprintf("%s %s is not a multiple of %s", name, v, step)
+		}
+	}
+	return ""
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
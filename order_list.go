@@ -0,0 +1,261 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+const (
+	ocoPath           = "/api/v3/orderList/oco"
+	orderListPath     = "/api/v3/orderList"
+	allOrderListPath  = "/api/v3/allOrderList"
+	openOrderListPath = "/api/v3/openOrderList"
+)
+
+func init() {
+	requireSignature(ocoPath, orderListPath, allOrderListPath, openOrderListPath)
+	countsAsOrder(http.MethodPost, 2, ocoPath)
+	setWeight(http.MethodPost, ocoPath, 1)
+	setWeight(http.MethodDelete, orderListPath, 1)
+	setWeight(http.MethodGet, orderListPath, 4)
+	setWeight(http.MethodGet, allOrderListPath, 20)
+	setWeight(http.MethodGet, openOrderListPath, 6)
+}
+
+// OCOLeg holds the parameters of one of the orders of an OCO order list. Type is required.
+// Price is required for the limit types, StopPrice or TrailingDelta for the stop types and
+// TimeInForce for STOP_LOSS_LIMIT and TAKE_PROFIT_LIMIT.
+type OCOLeg struct {
+	Type          model.OrderType
+	ClientOrderID string
+	Price         model.Decimal
+	StopPrice     model.Decimal
+	TrailingDelta int64
+	TimeInForce   model.TimeInForce
+	IcebergQty    model.Decimal
+}
+
+// OCOParams hold the parameters for a new OCO (one-cancels-the-other) order list. Above is
+// the order priced above the market, Below the one priced below. Quantity is required.
+type OCOParams struct {
+	ListClientOrderID string
+	Quantity          model.Decimal
+	Above             OCOLeg
+	Below             OCOLeg
+	NewOrderRespType  model.OrderResponseType
+	RecvWindow        int64
+}
+
+func (a *api) NewOCO(symbol string, side model.OrderSide, params OCOParams) (model.OrderList, error) {
+	return a.NewOCOContext(context.Background(), symbol, side, params)
+}
+
+func (a *api) NewOCOContext(ctx context.Context, symbol string, side model.OrderSide, params OCOParams) (ol model.OrderList, err error) {
+	if symbol == "" {
+		return ol, NoSymbolProvided
+	}
+	if err := checkOCOParams(params); err != nil {
+		return ol, err
+	}
+
+	p := NewParameters(20)
+	p.Set("symbol", symbol)
+	p.Set("side", string(side))
+	addOCOParams(p, params)
+
+	body, err := a.RequestContext(ctx, http.MethodPost, ocoPath, p)
+	if err != nil {
+		a.refreshOnOrderError(symbol, err)
+		return ol, err
+	}
+
+	err = json.Unmarshal(body, &ol)
+	if err != nil {
+		return ol, fmt.Errorf("encountered error while unmarshaling '%s' into model.OrderList", body)
+	}
+
+	return ol, nil
+}
+
+func (a *api) CancelOrderList(symbol string, orderListID int, listClientOrderID string) (model.OrderList, error) {
+	return a.CancelOrderListContext(context.Background(), symbol, orderListID, listClientOrderID)
+}
+
+func (a *api) CancelOrderListContext(ctx context.Context, symbol string, orderListID int, listClientOrderID string) (ol model.OrderList, err error) {
+	if symbol == "" {
+		return ol, NoSymbolProvided
+	}
+	q, err := orderListIdentifier(orderListID, "listClientOrderId", listClientOrderID)
+	if err != nil {
+		return ol, err
+	}
+	q.Set("symbol", symbol)
+
+	return a.orderList(ctx, http.MethodDelete, q)
+}
+
+func (a *api) QueryOrderList(orderListID int, origClientOrderID string) (model.OrderList, error) {
+	return a.QueryOrderListContext(context.Background(), orderListID, origClientOrderID)
+}
+
+func (a *api) QueryOrderListContext(ctx context.Context, orderListID int, origClientOrderID string) (ol model.OrderList, err error) {
+	q, err := orderListIdentifier(orderListID, "origClientOrderId", origClientOrderID)
+	if err != nil {
+		return ol, err
+	}
+
+	return a.orderList(ctx, http.MethodGet, q)
+}
+
+func (a *api) AllOrderLists(fromID int, startTime, endTime int64, limit int) ([]model.OrderList, error) {
+	return a.AllOrderListsContext(context.Background(), fromID, startTime, endTime, limit)
+}
+
+func (a *api) AllOrderListsContext(ctx context.Context, fromID int, startTime, endTime int64, limit int) ([]model.OrderList, error) {
+	q := NewParameters(4)
+	if fromID != 0 {
+		q.Set("fromId", strconv.Itoa(fromID))
+	}
+	if startTime != 0 {
+		q.Set("startTime", strconv.FormatInt(startTime, 10))
+	}
+	if endTime != 0 {
+		q.Set("endTime", strconv.FormatInt(endTime, 10))
+	}
+	if limit != 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	return a.orderLists(ctx, allOrderListPath, q)
+}
+
+func (a *api) OpenOrderLists() ([]model.OrderList, error) {
+	return a.OpenOrderListsContext(context.Background())
+}
+
+func (a *api) OpenOrderListsContext(ctx context.Context) ([]model.OrderList, error) {
+	return a.orderLists(ctx, openOrderListPath, nil)
+}
+
+func (a *api) orderList(ctx context.Context, method string, q Parameters) (ol model.OrderList, err error) {
+	body, err := a.RequestContext(ctx, method, orderListPath, q)
+	if err != nil {
+		return ol, err
+	}
+
+	err = json.Unmarshal(body, &ol)
+	if err != nil {
+		return ol, fmt.Errorf("encountered error while unmarshaling '%s' into model.OrderList", body)
+	}
+
+	return ol, nil
+}
+
+func (a *api) orderLists(ctx context.Context, path string, q Parameters) (ol []model.OrderList, err error) {
+	body, err := a.RequestContext(ctx, http.MethodGet, path, q)
+	if err != nil {
+		return ol, err
+	}
+
+	err = json.Unmarshal(body, &ol)
+	if err != nil {
+		return ol, fmt.Errorf("encountered error while unmarshaling '%s' into model.OrderList", body)
+	}
+
+	return ol, nil
+}
+
+// orderListIdentifier returns parameters identifying an order list by either its id
+// or the client order id stored under clientIDKey
+func orderListIdentifier(orderListID int, clientIDKey, clientID string) (Parameters, error) {
+	if orderListID == 0 && clientID == "" {
+		return nil, NoOrderIDProvided
+	}
+	q := NewParameters(3)
+	if orderListID != 0 {
+		q.Set("orderListId", strconv.Itoa(orderListID))
+	}
+	if clientID != "" {
+		q.Set(clientIDKey, clientID)
+	}
+	return q, nil
+}
+
+func addOCOParams(p Parameters, params OCOParams) {
+	if params.ListClientOrderID != "" {
+		p.Set("listClientOrderId", params.ListClientOrderID)
+	}
+	p.Set("quantity", params.Quantity.String())
+	addOCOLeg(p, "above", params.Above)
+	addOCOLeg(p, "below", params.Below)
+	if params.NewOrderRespType != "" {
+		p.Set("newOrderRespType", string(params.NewOrderRespType))
+	}
+	if params.RecvWindow != 0 {
+		p.Set("recvWindow", strconv.FormatInt(params.RecvWindow, 10))
+	}
+}
+
+// addOCOLeg sets the parameters of a leg, prefixed with above or below
+func addOCOLeg(p Parameters, prefix string, leg OCOLeg) {
+	p.Set(prefix+"Type", string(leg.Type))
+	if leg.ClientOrderID != "" {
+		p.Set(prefix+"ClientOrderId", leg.ClientOrderID)
+	}
+	if !leg.Price.IsZero() {
+		p.Set(prefix+"Price", leg.Price.String())
+	}
+	if !leg.StopPrice.IsZero() {
+		p.Set(prefix+"StopPrice", leg.StopPrice.String())
+	}
+	if leg.TrailingDelta != 0 {
+		p.Set(prefix+"TrailingDelta", strconv.FormatInt(leg.TrailingDelta, 10))
+	}
+	if leg.TimeInForce != "" {
+		p.Set(prefix+"TimeInForce", string(leg.TimeInForce))
+	}
+	if !leg.IcebergQty.IsZero() {
+		p.Set(prefix+"IcebergQty", leg.IcebergQty.String())
+	}
+}
+
+func checkOCOParams(params OCOParams) error {
+	if params.Quantity.IsZero() {
+		return errors.New("required: Quantity")
+	}
+	if err := checkOCOLeg("Above", params.Above); err != nil {
+		return err
+	}
+	return checkOCOLeg("Below", params.Below)
+}
+
+func checkOCOLeg(name string, leg OCOLeg) error {
+	switch leg.Type {
+	case model.LimitMaker:
+		if leg.Price.IsZero() {
+			return fmt.Errorf("required: %s.Price for %s", name, leg.Type)
+		}
+	case model.StopLoss, model.TakeProfit:
+		if leg.StopPrice.IsZero() && leg.TrailingDelta == 0 {
+			return fmt.Errorf("required: %s.StopPrice or %s.TrailingDelta for %s", name, name, leg.Type)
+		}
+	case model.StopLossLimit, model.TakeProfitLimit:
+		if leg.Price.IsZero() || leg.TimeInForce == "" {
+			return fmt.Errorf("required: %s.Price and %s.TimeInForce for %s", name, name, leg.Type)
+		}
+		if leg.StopPrice.IsZero() && leg.TrailingDelta == 0 {
+			return fmt.Errorf("required: %s.StopPrice or %s.TrailingDelta for %s", name, name, leg.Type)
+		}
+	case "":
+		return fmt.Errorf("required: %s.Type", name)
+	default:
+		return fmt.Errorf("order type %s is not supported in an OCO", leg.Type)
+	}
+	return nil
+}
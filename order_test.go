@@ -1,7 +1,10 @@
 package binance_test
 
 import (
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 
 	. "github.com/onsi/gomega"
 
@@ -38,11 +41,11 @@ var _ = Describe("Order", func() {
 			a := newAPI(ts.URL)
 			ap, err := a.Order("DOGEUSDT", model.Buy, model.TakeProfitLimit, binance.OrderParams{
 				TimeInForce:      model.GoodTilCanceled,
-				Quantity:         50,
-				QuoteOrderQty:    0,
-				Price:            0.495,
-				StopPrice:        0.50,
-				IcebergQty:       0,
+				Quantity:         model.MustParseDecimal("50"),
+				QuoteOrderQty:    model.MustParseDecimal("0"),
+				Price:            model.MustParseDecimal("0.495"),
+				StopPrice:        model.MustParseDecimal("0.50"),
+				IcebergQty:       model.MustParseDecimal("0"),
 				NewOrderRespType: model.Ack,
 				RecvWindow:       0,
 			})
@@ -51,4 +54,400 @@ var _ = Describe("Order", func() {
 			Expect(ok).To(BeTrue())
 		})
 	})
+
+	Context("Should cancel orders in the Binance system", func() {
+		It("should cancel an order by its id", func() {
+			var method string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				method = r.Method
+				Expect(r.URL.Path).To(Equal("/api/v3/order"))
+				Expect(r.URL.Query().Get("orderId")).To(Equal("123456789"))
+				Expect(r.URL.Query().Get("signature")).ToNot(BeEmpty())
+				_, _ = w.Write(loadFixture("cancel_order_data"))
+			}))
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			co, err := a.CancelOrder("DOGEUSDT", 123456789, "")
+			Expect(err).To(BeNil())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(co.OrderID).To(Equal(123456789))
+			Expect(co.Status).To(Equal("CANCELED"))
+			Expect(co.Side).To(Equal(model.Buy))
+		})
+
+		It("should require an order identifier", func() {
+			a := newAPI("http://mies.mees")
+			_, err := a.CancelOrder("DOGEUSDT", 0, "")
+			Expect(err).To(Equal(binance.NoOrderIDProvided))
+		})
+
+		It("should cancel all open orders and order lists", func() {
+			ts := testServer("/api/v3/openOrders", map[string]struct{}{
+				"symbol":    {},
+				"timestamp": {},
+				"signature": {},
+			}, http.StatusOK, loadFixture("cancel_open_orders_data"), nil)
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			co, ol, err := a.CancelAllOpenOrders("BTCUSDT")
+			Expect(err).To(BeNil())
+			Expect(co).To(HaveLen(1))
+			Expect(co[0].OrderID).To(Equal(11))
+			Expect(co[0].Status).To(Equal("CANCELED"))
+			Expect(ol).To(HaveLen(1))
+			Expect(ol[0].OrderListID).To(Equal(1929))
+			Expect(ol[0].ContingencyType).To(Equal("OCO"))
+			Expect(ol[0].Orders).To(HaveLen(2))
+			Expect(ol[0].OrderReports).To(HaveLen(2))
+			Expect(ol[0].OrderReports[0].Type).To(Equal(model.StopLossLimit))
+		})
+	})
+
+	Context("Should query orders in the Binance system", func() {
+		It("should return the open orders", func() {
+			ts := testServer("/api/v3/openOrders", map[string]struct{}{
+				"symbol":    {},
+				"timestamp": {},
+				"signature": {},
+			}, http.StatusOK, loadFixture("open_orders_data"), nil)
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			orders, err := a.OpenOrders("DOGEUSDT")
+			Expect(err).To(BeNil())
+			Expect(orders).To(HaveLen(1))
+			Expect(orders[0].OrderID).To(Equal(123456789))
+			Expect(orders[0].Status).To(Equal("NEW"))
+		})
+
+		It("should query a single order by client order id", func() {
+			ts := testServer("/api/v3/order", map[string]struct{}{
+				"symbol":            {},
+				"origClientOrderId": {},
+				"timestamp":         {},
+				"signature":         {},
+			}, http.StatusOK, []byte(`{"symbol":"DOGEUSDT","orderId":123456789,"clientOrderId":"sdfkljsdfLDF90SDFjlsdf"}`), nil)
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			order, err := a.QueryOrder("DOGEUSDT", 0, "sdfkljsdfLDF90SDFjlsdf")
+			Expect(err).To(BeNil())
+			Expect(order.OrderID).To(Equal(123456789))
+		})
+	})
+
+	Context("Should manage order lists in the Binance system", func() {
+		It("should place an OCO order", func() {
+			var form url.Values
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.URL.Path).To(Equal("/api/v3/orderList/oco"))
+				Expect(r.ParseForm()).To(Succeed())
+				form = r.PostForm
+				_, _ = w.Write(loadFixture("oco_order_data"))
+			}))
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			ol, err := a.NewOCO("LTCBTC", model.Buy, binance.OCOParams{
+				Quantity: model.MustParseDecimal("0.624363"),
+				Above: binance.OCOLeg{
+					Type:      model.StopLoss,
+					StopPrice: model.MustParseDecimal("0.960664"),
+				},
+				Below: binance.OCOLeg{
+					Type:  model.LimitMaker,
+					Price: model.MustParseDecimal("0.036435"),
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(form.Get("aboveType")).To(Equal("STOP_LOSS"))
+			Expect(form.Get("aboveStopPrice")).To(Equal("0.960664"))
+			Expect(form.Get("belowType")).To(Equal("LIMIT_MAKER"))
+			Expect(form.Get("belowPrice")).To(Equal("0.036435"))
+			Expect(form.Get("quantity")).To(Equal("0.624363"))
+			Expect(ol.ContingencyType).To(Equal("OCO"))
+			Expect(ol.Orders).To(HaveLen(2))
+			Expect(ol.OrderReports).To(HaveLen(2))
+			Expect(ol.OrderReports[0].Type).To(Equal(model.StopLoss))
+			Expect(ol.OrderReports[0].StopPrice.String()).To(Equal("0.960664"))
+		})
+
+		It("should validate the OCO parameters", func() {
+			a := newAPI("http://mies.mees")
+			_, err := a.NewOCO("LTCBTC", model.Buy, binance.OCOParams{
+				Quantity: model.MustParseDecimal("0.624363"),
+				Above: binance.OCOLeg{
+					Type:      model.StopLossLimit,
+					Price:     model.MustParseDecimal("0.96"),
+					StopPrice: model.MustParseDecimal("0.960664"),
+				},
+				Below: binance.OCOLeg{
+					Type:  model.LimitMaker,
+					Price: model.MustParseDecimal("0.036435"),
+				},
+			})
+			Expect(err).To(MatchError("required: Above.Price and Above.TimeInForce for STOP_LOSS_LIMIT"))
+
+			_, err = a.NewOCO("LTCBTC", model.Buy, binance.OCOParams{
+				Quantity: model.MustParseDecimal("0.624363"),
+				Above:    binance.OCOLeg{Type: model.LimitMaker, Price: model.MustParseDecimal("0.96")},
+				Below:    binance.OCOLeg{Type: model.Market},
+			})
+			Expect(err).To(MatchError("order type MARKET is not supported in an OCO"))
+		})
+
+		It("should require an order list identifier", func() {
+			a := newAPI("http://mies.mees")
+			_, err := a.QueryOrderList(0, "")
+			Expect(err).To(Equal(binance.NoOrderIDProvided))
+		})
+	})
+
+	Context("Should cancel and replace orders in the Binance system", func() {
+		params := binance.OrderParams{
+			TimeInForce: model.GoodTilCanceled,
+			Quantity:    model.MustParseDecimal("0.0001"),
+			Price:       model.MustParseDecimal("0.02"),
+		}
+
+		It("should return both results", func() {
+			ts := testServer("/api/v3/order/cancelReplace", map[string]struct{}{}, http.StatusOK, loadFixture("cancel_replace_data"), nil)
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			cr, err := a.CancelReplaceOrder("BTCUSDT", model.Sell, model.Limit, binance.CancelReplaceParams{CancelOrderID: 9}, params)
+			Expect(err).To(BeNil())
+			Expect(cr.CancelResult).To(Equal(model.Success))
+			Expect(cr.CancelResponse.OrderID).To(Equal(9))
+			Expect(cr.NewOrderResponse.OrderID()).To(Equal(10))
+		})
+
+		It("should return a typed error on partial failure", func() {
+			ts := testServer("/api/v3/order/cancelReplace", nil, http.StatusBadRequest, loadFixture("cancel_replace_partial_data"), nil)
+			defer ts.Close()
+
+			a := newAPI(ts.URL)
+			cr, err := a.CancelReplaceOrder("BTCUSDT", model.Sell, model.Limit, binance.CancelReplaceParams{
+				Mode:          model.AllowFailure,
+				CancelOrderID: 9,
+			}, params)
+			Expect(err).ToNot(BeNil())
+			Expect(errors.Is(err, binance.ErrCodeCancelReplacePartiallyFailed)).To(BeTrue())
+
+			var crErr binance.CancelReplaceError
+			Expect(errors.As(err, &crErr)).To(BeTrue())
+			Expect(crErr.Response.CancelError.Code).To(Equal(-2011))
+			Expect(cr.CancelResult).To(Equal(model.Failure))
+			Expect(cr.NewOrderResponse.OrderID()).To(Equal(11))
+		})
+	})
+
+	Context("Should validate the order parameters", func() {
+		var a binance.APICaller
+
+		BeforeEach(func() {
+			a = newAPI("http://mies.mees")
+		})
+
+		It("should accept a trailing delta as stop trigger", func() {
+			ts := testServer("/api/v3/order/test", map[string]struct{}{
+				"symbol":                  {},
+				"side":                    {},
+				"type":                    {},
+				"quantity":                {},
+				"newClientOrderId":        {},
+				"trailingDelta":           {},
+				"strategyId":              {},
+				"strategyType":            {},
+				"selfTradePreventionMode": {},
+				"timestamp":               {},
+				"signature":               {},
+			}, http.StatusOK, []byte("{}"), nil)
+			defer ts.Close()
+
+			_, err := newAPI(ts.URL).OrderTest("DOGEUSDT", model.Sell, model.StopLoss, binance.OrderParams{
+				Quantity:                model.MustParseDecimal("50"),
+				NewClientOrderID:        "my-order_1",
+				TrailingDelta:           100,
+				StrategyID:              1,
+				StrategyType:            1000000,
+				SelfTradePreventionMode: model.ExpireTaker,
+			})
+			Expect(err).To(BeNil())
+		})
+
+		It("should require a stop price or trailing delta on stop orders", func() {
+			_, err := a.OrderTest("DOGEUSDT", model.Sell, model.StopLoss, binance.OrderParams{Quantity: model.MustParseDecimal("50")})
+			Expect(err).To(MatchError("required: StopPrice or TrailingDelta"))
+		})
+
+		It("should refuse a trailing delta on limit orders", func() {
+			_, err := a.OrderTest("DOGEUSDT", model.Sell, model.Limit, binance.OrderParams{
+				TimeInForce:   model.GoodTilCanceled,
+				Quantity:      model.MustParseDecimal("50"),
+				Price:         model.MustParseDecimal("0.5"),
+				TrailingDelta: 100,
+			})
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should require GTC on iceberg orders", func() {
+			_, err := a.OrderTest("DOGEUSDT", model.Sell, model.Limit, binance.OrderParams{
+				TimeInForce: model.ImmediateOrCancel,
+				Quantity:    model.MustParseDecimal("50"),
+				Price:       model.MustParseDecimal("0.5"),
+				IcebergQty:  model.MustParseDecimal("10"),
+			})
+			Expect(err).To(MatchError("IcebergQty requires TimeInForce GTC"))
+		})
+
+		It("should refuse reserved strategy types and invalid client order ids", func() {
+			params := binance.OrderParams{Quantity: model.MustParseDecimal("50"), StrategyType: 10}
+			_, err := a.OrderTest("DOGEUSDT", model.Sell, model.Market, params)
+			Expect(err).ToNot(BeNil())
+
+			params = binance.OrderParams{Quantity: model.MustParseDecimal("50"), NewClientOrderID: "no spaces allowed"}
+			_, err = a.OrderTest("DOGEUSDT", model.Sell, model.Market, params)
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
+	Context("Should validate orders against the exchange filters", func() {
+		var (
+			ts     *httptest.Server
+			a      binance.APICaller
+			orders int
+			form   url.Values
+		)
+
+		BeforeEach(func() {
+			orders = 0
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v3/exchangeInfo" {
+					_, _ = w.Write(loadFixture("exchange_info_data"))
+					return
+				}
+				orders++
+				_ = r.ParseForm()
+				form = r.PostForm
+				_, _ = w.Write([]byte("{}"))
+			}))
+
+			var err error
+			a, err = binance.NewAPICaller(binance.APIConfig{
+				Key:            apiKey,
+				Secret:         apiSecret,
+				BaseURI:        ts.URL,
+				ValidateOrders: true,
+			})
+			Expect(err).To(BeNil())
+		})
+
+		AfterEach(func() {
+			ts.Close()
+		})
+
+		It("should send valid orders", func() {
+			_, err := a.OrderTest("DOGEUSDT", model.Buy, model.Limit, binance.OrderParams{
+				TimeInForce: model.GoodTilCanceled,
+				Quantity:    model.MustParseDecimal("50"),
+				Price:       model.MustParseDecimal("0.12345"),
+			})
+			Expect(err).To(BeNil())
+			Expect(orders).To(Equal(1))
+		})
+
+		It("should refuse a price off the tick size", func() {
+			_, err := a.OrderTest("DOGEUSDT", model.Buy, model.Limit, binance.OrderParams{
+				TimeInForce: model.GoodTilCanceled,
+				Quantity:    model.MustParseDecimal("50"),
+				Price:       model.MustParseDecimal("0.123456"),
+			})
+			var fe binance.FilterError
+			Expect(errors.As(err, &fe)).To(BeTrue())
+			Expect(fe.Filter).To(Equal(model.PriceFilterType))
+			Expect(err).To(MatchError("order on DOGEUSDT violates PRICE_FILTER: price 0.123456 is not a multiple of 0.00001000"))
+			Expect(errors.Is(err, binance.ErrCodeFilterFailure)).To(BeTrue())
+			Expect(orders).To(Equal(0))
+		})
+
+		It("should refuse quantities and notionals out of range", func() {
+			_, err := a.OrderTest("DOGEUSDT", model.Sell, model.Market, binance.OrderParams{Quantity: model.MustParseDecimal("2.5")})
+			Expect(err).To(MatchError(ContainSubstring("LOT_SIZE")))
+
+			_, err = a.OrderTest("DOGEUSDT", model.Sell, model.Market, binance.OrderParams{Quantity: model.MustParseDecimal("3000000")})
+			Expect(err).To(MatchError(ContainSubstring("MARKET_LOT_SIZE")))
+
+			_, err = a.OrderTest("DOGEUSDT", model.Buy, model.Limit, binance.OrderParams{
+				TimeInForce: model.GoodTilCanceled,
+				Quantity:    model.MustParseDecimal("10"),
+				Price:       model.MustParseDecimal("0.1"),
+			})
+			Expect(err).To(MatchError("order on DOGEUSDT violates NOTIONAL: notional 1.0 is below the minimum of 5.00000000"))
+			Expect(orders).To(Equal(0))
+		})
+
+		It("should refuse too many iceberg parts and trailing deltas out of range", func() {
+			_, err := a.OrderTest("DOGEUSDT", model.Buy, model.Limit, binance.OrderParams{
+				TimeInForce: model.GoodTilCanceled,
+				Quantity:    model.MustParseDecimal("100"),
+				Price:       model.MustParseDecimal("0.1"),
+				IcebergQty:  model.MustParseDecimal("9"),
+			})
+			Expect(err).To(MatchError(ContainSubstring("ICEBERG_PARTS")))
+
+			_, err = a.OrderTest("DOGEUSDT", model.Sell, model.StopLoss, binance.OrderParams{
+				Quantity:      model.MustParseDecimal("100"),
+				TrailingDelta: 5,
+			})
+			Expect(err).To(MatchError(ContainSubstring("TRAILING_DELTA")))
+		})
+
+		It("should refuse orders the symbol does not allow", func() {
+			_, err := a.OrderTest("DOGEEUR", model.Buy, model.Market, binance.OrderParams{Quantity: model.MustParseDecimal("50")})
+			Expect(err).To(MatchError("order on DOGEEUR is not allowed: symbol is not trading, status is BREAK"))
+			Expect(errors.Is(err, binance.ErrCodeFilterFailure)).To(BeTrue())
+
+			_, err = a.OrderTest("DOGEBTC", model.Buy, model.StopLoss, binance.OrderParams{
+				Quantity:  model.MustParseDecimal("50"),
+				StopPrice: model.MustParseDecimal("0.1"),
+			})
+			Expect(err).To(MatchError("order on DOGEBTC is not allowed: order type STOP_LOSS is not allowed"))
+			Expect(errors.Is(err, binance.ErrCodeFilterFailure)).To(BeTrue())
+
+			_, err = a.OrderTest("DOGEBTC", model.Buy, model.Limit, binance.OrderParams{
+				TimeInForce: model.GoodTilCanceled,
+				Quantity:    model.MustParseDecimal("50"),
+				Price:       model.MustParseDecimal("0.1"),
+				IcebergQty:  model.MustParseDecimal("10"),
+			})
+			var fe binance.FilterError
+			Expect(errors.As(err, &fe)).To(BeTrue())
+			Expect(fe.Filter).To(BeEmpty())
+			Expect(fe.Reason).To(Equal("iceberg orders are not allowed"))
+			Expect(orders).To(Equal(0))
+		})
+
+		It("should round the order to the filters", func() {
+			_, err := a.OrderTest("DOGEUSDT", model.Sell, model.Limit, binance.OrderParams{
+				TimeInForce:    model.GoodTilCanceled,
+				Quantity:       model.MustParseDecimal("50.7"),
+				Price:          model.MustParseDecimal("0.123451"),
+				RoundToFilters: true,
+			})
+			Expect(err).To(BeNil())
+			Expect(form.Get("quantity")).To(Equal("50.00000000"))
+			Expect(form.Get("price")).To(Equal("0.12346000"))
+		})
+
+		It("should refuse unknown symbols", func() {
+			_, err := a.OrderTest("AAPNOOT", model.Sell, model.Market, binance.OrderParams{Quantity: model.MustParseDecimal("1")})
+			Expect(errors.Is(err, binance.ErrCodeBadSymbol)).To(BeTrue())
+		})
+	})
 })
@@ -1,30 +1,52 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/jaztec/go-binance/model"
 )
 
 const (
-	depthPath     = "/api/v3/depth"
-	allOrdersPath = "/api/v3/allOrders"
-	orderPath     = "/api/v3/order"
-	orderTestPath = "/api/v3/order/test"
+	depthPath      = "/api/v3/depth"
+	allOrdersPath  = "/api/v3/allOrders"
+	openOrdersPath = "/api/v3/openOrders"
+	orderPath      = "/api/v3/order"
+	orderTestPath  = "/api/v3/order/test"
 )
 
+// minStrategyType is the lowest value Binance accepts for a strategy type, lower values are reserved
+const minStrategyType = 1000000
+
+var clientOrderIDPattern = regexp.MustCompile(`^[\.A-Z:/a-z0-9_-]{1,36}$`)
+
 func init() {
-	requireSignature(allOrdersPath, orderPath, orderTestPath)
+	requireSignature(allOrdersPath, openOrdersPath, orderPath, orderTestPath)
+	setWeight(http.MethodGet, allOrdersPath, 20)
+	setWeight(http.MethodGet, orderPath, 4)
+	setWeightFunc(http.MethodGet, openOrdersPath, func(p Parameters) int {
+		if paramValue(p, "symbol") != "" {
+			return 6
+		}
+		return 80
+	})
+	setWeightFunc(http.MethodGet, depthPath, depthWeight)
+	countsAsOrder(http.MethodPost, 1, orderPath)
+	retrySafe(http.MethodPost, orderTestPath)
 }
 
 func (a *api) AllOrders(symbol string, startTime, endTime int64, limit int) (uo []model.UserOrder, err error) {
+	return a.AllOrdersContext(context.Background(), symbol, startTime, endTime, limit)
+}
+
+func (a *api) AllOrdersContext(ctx context.Context, symbol string, startTime, endTime int64, limit int) (uo []model.UserOrder, err error) {
 	if symbol == "" {
 		return uo, NoSymbolProvided
 	}
@@ -39,9 +61,58 @@ func (a *api) AllOrders(symbol string, startTime, endTime int64, limit int) (uo
 	if limit != 0 {
 		q.Set("limit", strconv.Itoa(limit))
 	}
-	q.Set("timestamp", strconv.FormatInt(time.Now().Unix()*1000, 10))
 
-	body, err := a.Request(http.MethodGet, allOrdersPath, q)
+	body, err := a.RequestContext(ctx, http.MethodGet, allOrdersPath, q)
+	if err != nil {
+		return uo, err
+	}
+
+	err = json.Unmarshal(body, &uo)
+	if err != nil {
+		return uo, fmt.Errorf("encountered error while unmarshaling '%s' into model.UserOrder", body)
+	}
+
+	return uo, nil
+}
+
+func (a *api) QueryOrder(symbol string, orderID int, origClientOrderID string) (model.UserOrder, error) {
+	return a.QueryOrderContext(context.Background(), symbol, orderID, origClientOrderID)
+}
+
+func (a *api) QueryOrderContext(ctx context.Context, symbol string, orderID int, origClientOrderID string) (uo model.UserOrder, err error) {
+	if symbol == "" {
+		return uo, NoSymbolProvided
+	}
+	q, err := orderIdentifier(symbol, orderID, origClientOrderID)
+	if err != nil {
+		return uo, err
+	}
+
+	body, err := a.RequestContext(ctx, http.MethodGet, orderPath, q)
+	if err != nil {
+		return uo, err
+	}
+
+	err = json.Unmarshal(body, &uo)
+	if err != nil {
+		return uo, fmt.Errorf("encountered error while unmarshaling '%s' into model.UserOrder", body)
+	}
+
+	return uo, nil
+}
+
+func (a *api) OpenOrders(symbol string) ([]model.UserOrder, error) {
+	return a.OpenOrdersContext(context.Background(), symbol)
+}
+
+func (a *api) OpenOrdersContext(ctx context.Context, symbol string) (uo []model.UserOrder, err error) {
+	var q Parameters
+	if symbol != "" {
+		q = NewParameters(1)
+		q.Set("symbol", symbol)
+	}
+
+	body, err := a.RequestContext(ctx, http.MethodGet, openOrdersPath, q)
 	if err != nil {
 		return uo, err
 	}
@@ -55,16 +126,25 @@ func (a *api) AllOrders(symbol string, startTime, endTime int64, limit int) (uo
 }
 
 func (a *api) Depth(symbol string, limit int) (o model.Orders, err error) {
+	return a.DepthContext(context.Background(), symbol, limit)
+}
+
+func (a *api) DepthContext(ctx context.Context, symbol string, limit int) (o model.Orders, err error) {
 	if symbol == "" {
 		return o, NoSymbolProvided
 	}
+	if limit != 0 {
+		if err = checkDepthLimit(limit); err != nil {
+			return o, err
+		}
+	}
 	q := NewParameters(2)
 	q.Set("symbol", symbol)
 	if limit != 0 {
 		q.Set("limit", strconv.Itoa(limit))
 	}
 
-	body, err := a.Request(http.MethodGet, depthPath, q)
+	body, err := a.RequestContext(ctx, http.MethodGet, depthPath, q)
 	if err != nil {
 		return o, err
 	}
@@ -77,31 +157,95 @@ func (a *api) Depth(symbol string, limit int) (o model.Orders, err error) {
 	return o, nil
 }
 
+// maxDepthLimit is the deepest book the depth endpoint returns
+const maxDepthLimit = 5000
+
+// depthLimitError reports a depth limit Binance does not accept, it matches InvalidDepthLimit
+type depthLimitError int
+
+func (e depthLimitError) Error() string {
+	return fmt.Sprintf("invalid depth limit %d, valid limits are 1 to %d", int(e), maxDepthLimit)
+}
+
+// Is reports a match with InvalidDepthLimit
+func (e depthLimitError) Is(target error) bool {
+	return target == InvalidDepthLimit
+}
+
+// checkDepthLimit returns an error matching InvalidDepthLimit when Binance does not accept the limit
+func checkDepthLimit(limit int) error {
+	if limit < 1 || limit > maxDepthLimit {
+		return depthLimitError(limit)
+	}
+	return nil
+}
+
+func depthWeight(p Parameters) int {
+	limit, _ := strconv.Atoi(paramValue(p, "limit"))
+	switch {
+	case limit <= 100:
+		return 5
+	case limit <= 500:
+		return 25
+	case limit <= 1000:
+		return 50
+	default:
+		return 250
+	}
+}
+
 // OrderParams hold all optional parameters for a new order. Some parameters
 // may still be enforced depending on the OrderType
 type OrderParams struct {
 	TimeInForce      model.TimeInForce
-	Quantity         float64
-	QuoteOrderQty    float64
-	Price            float64
-	StopPrice        float64
-	IcebergQty       float64
-	NewOrderRespType model.OrderResponseType
-	RecvWindow       int64
+	Quantity         model.Decimal
+	QuoteOrderQty    model.Decimal
+	Price            model.Decimal
+	NewClientOrderID string
+	StopPrice        model.Decimal
+	// TrailingDelta in BIPS for trailing stop orders
+	TrailingDelta int64
+	IcebergQty    model.Decimal
+	StrategyID    int64
+	// StrategyType must be at least 1000000 when set
+	StrategyType            int64
+	SelfTradePreventionMode model.SelfTradePreventionMode
+	NewOrderRespType        model.OrderResponseType
+	RecvWindow              int64
+	// RoundToFilters snaps Price and StopPrice to the tickSize and Quantity and IcebergQty
+	// to the stepSize of the symbol before sending the order. Prices round down when buying
+	// and up when selling, quantities always round down. The symbol information is fetched
+	// when it is not cached
+	RoundToFilters bool
 }
 
 func (a *api) Order(symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error) {
-	return a.doOrder(orderPath, symbol, side, orderType, params)
+	return a.OrderContext(context.Background(), symbol, side, orderType, params)
+}
+
+func (a *api) OrderContext(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error) {
+	return a.doOrder(ctx, orderPath, symbol, side, orderType, params)
 }
 
 func (a *api) OrderTest(symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error) {
-	return a.doOrder(orderTestPath, symbol, side, orderType, params)
+	return a.OrderTestContext(context.Background(), symbol, side, orderType, params)
 }
 
-func (a *api) doOrder(path string, symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error) {
+func (a *api) OrderTestContext(ctx context.Context, symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error) {
+	return a.doOrder(ctx, orderTestPath, symbol, side, orderType, params)
+}
+
+func (a *api) doOrder(ctx context.Context, path string, symbol string, side model.OrderSide, orderType model.OrderType, params OrderParams) (model.OrderResponse, error) {
+	params, err := a.roundOrderParams(ctx, symbol, side, params)
+	if err != nil {
+		return nil, err
+	}
 	if err := checkOrderParams(orderType, params); err != nil {
 		return nil, err
 	}
+	if err := a.validateOrder(ctx, symbol, side, orderType, params); err != nil {
+		return nil, err
+	}
 
 	p := NewParameters(11)
 	p.Set("symbol", symbol)
@@ -110,10 +254,9 @@ func (a *api) doOrder(path string, symbol string, side model.OrderSide, orderTyp
 
 	addOrderParams(p, params)
 
-	p.Set("timestamp", strconv.FormatInt(time.Now().Unix()*1000, 10))
-
-	res, err := a.Request(http.MethodPost, path, p)
+	res, err := a.RequestContext(ctx, http.MethodPost, path, p)
 	if err != nil {
+		a.refreshOnOrderError(symbol, err)
 		return nil, err
 	}
 
@@ -151,20 +294,35 @@ func addOrderParams(p Parameters, params OrderParams) {
 	if params.TimeInForce != "" {
 		p.Set("timeInForce", string(params.TimeInForce))
 	}
-	if params.Quantity != 0.0 {
-		p.Set("quantity", fmt.Sprintf("%.8f", params.Quantity))
+	if !params.Quantity.IsZero() {
+		p.Set("quantity", params.Quantity.String())
+	}
+	if !params.QuoteOrderQty.IsZero() {
+		p.Set("quoteOrderQty", params.QuoteOrderQty.String())
 	}
-	if params.QuoteOrderQty != 0.0 {
-		p.Set("quoteOrderQty", fmt.Sprintf("%.8f", params.QuoteOrderQty))
+	if !params.Price.IsZero() {
+		p.Set("price", params.Price.String())
 	}
-	if params.Price != 0.0 {
-		p.Set("price", fmt.Sprintf("%.8f", params.Price))
+	if params.NewClientOrderID != "" {
+		p.Set("newClientOrderId", params.NewClientOrderID)
 	}
-	if params.StopPrice != 0.0 {
-		p.Set("stopPrice", fmt.Sprintf("%.8f", params.StopPrice))
+	if !params.StopPrice.IsZero() {
+		p.Set("stopPrice", params.StopPrice.String())
 	}
-	if params.IcebergQty != 0.0 {
-		p.Set("icebergQty", fmt.Sprintf("%.8f", params.IcebergQty))
+	if params.TrailingDelta != 0 {
+		p.Set("trailingDelta", strconv.FormatInt(params.TrailingDelta, 10))
+	}
+	if !params.IcebergQty.IsZero() {
+		p.Set("icebergQty", params.IcebergQty.String())
+	}
+	if params.StrategyID != 0 {
+		p.Set("strategyId", strconv.FormatInt(params.StrategyID, 10))
+	}
+	if params.StrategyType != 0 {
+		p.Set("strategyType", strconv.FormatInt(params.StrategyType, 10))
+	}
+	if params.SelfTradePreventionMode != "" {
+		p.Set("selfTradePreventionMode", string(params.SelfTradePreventionMode))
 	}
 	if params.NewOrderRespType != "" {
 		p.Set("newOrderRespType", string(params.NewOrderRespType))
@@ -180,7 +338,12 @@ func checkOrderParams(ot model.OrderType, params OrderParams) error {
 		v := reflect.ValueOf(params)
 		for _, p := range n {
 			f := v.FieldByName(p)
-			if f.IsZero() {
+			zero := f.IsZero()
+			// decimals like "0.00" are zero without being the zero value
+			if d, ok := f.Interface().(model.Decimal); ok {
+				zero = d.IsZero()
+			}
+			if zero {
 				missing = append(missing, p)
 			}
 		}
@@ -192,19 +355,73 @@ func checkOrderParams(ot model.OrderType, params OrderParams) error {
 		return nil
 	}
 
+	// stop orders are triggered by either a stop price or a trailing delta
+	checkTrigger := func(err error) error {
+		if err == nil && params.StopPrice.IsZero() && params.TrailingDelta == 0 {
+			return errors.New("required: StopPrice or TrailingDelta")
+		}
+		return err
+	}
+
+	var err error
 	switch ot {
 	case model.Limit:
-		return check([]string{"TimeInForce", "Quantity", "Price"})
+		err = check([]string{"TimeInForce", "Quantity", "Price"})
 	case model.Market:
 		err1 := check([]string{"Quantity"})
 		err2 := check([]string{"QuoteOrderQty"})
 		if err1 != nil && err2 != nil {
-			return errors.New("required: Quantity or QuoteOrderQty")
+			err = errors.New("required: Quantity or QuoteOrderQty")
 		}
-	case model.LimitMaker, model.StopLoss, model.TakeProfit:
-		return check([]string{"Quantity", "StopPrice"})
+	case model.LimitMaker:
+		err = check([]string{"Quantity", "Price"})
+	case model.StopLoss, model.TakeProfit:
+		err = checkTrigger(check([]string{"Quantity"}))
 	case model.StopLossLimit, model.TakeProfitLimit:
-		return check([]string{"TimeInForce", "Quantity", "Price", "StopPrice"})
+		err = checkTrigger(check([]string{"TimeInForce", "Quantity", "Price"}))
+	}
+	if err != nil {
+		return err
+	}
+
+	return checkOptionalOrderParams(ot, params)
+}
+
+func checkOptionalOrderParams(ot model.OrderType, params OrderParams) error {
+	if params.NewClientOrderID != "" && !clientOrderIDPattern.MatchString(params.NewClientOrderID) {
+		return fmt.Errorf("invalid NewClientOrderID %q, expected %s", params.NewClientOrderID, clientOrderIDPattern)
+	}
+	if params.TrailingDelta != 0 {
+		switch ot {
+		case model.StopLoss, model.StopLossLimit, model.TakeProfit, model.TakeProfitLimit:
+		default:
+			return fmt.Errorf("TrailingDelta is not supported on %s orders", ot)
+		}
+		if params.TrailingDelta < 0 {
+			return errors.New("TrailingDelta must be positive")
+		}
+	}
+	if !params.IcebergQty.IsZero() {
+		switch ot {
+		case model.Limit, model.StopLossLimit, model.TakeProfitLimit:
+			if params.TimeInForce != model.GoodTilCanceled {
+				return errors.New("IcebergQty requires TimeInForce GTC")
+			}
+		case model.LimitMaker:
+		default:
+			return fmt.Errorf("IcebergQty is not supported on %s orders", ot)
+		}
+		if params.IcebergQty.Cmp(params.Quantity) >= 0 {
+			return errors.New("IcebergQty must be smaller than Quantity")
+		}
+	}
+	if params.StrategyType != 0 && params.StrategyType < minStrategyType {
+		return fmt.Errorf("StrategyType must be at least %d", minStrategyType)
+	}
+	switch params.SelfTradePreventionMode {
+	case "", model.ExpireTaker, model.ExpireMaker, model.ExpireBoth, model.NoPrevention:
+	default:
+		return fmt.Errorf("unknown SelfTradePreventionMode %s", params.SelfTradePreventionMode)
 	}
 	return nil
 }
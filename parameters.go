@@ -1,6 +1,7 @@
 package binance
 
 import (
+	"encoding/json"
 	"net/url"
 	"strings"
 )
@@ -11,6 +12,21 @@ type Parameters interface {
 	Set(string, ...string)
 }
 
+// paramGetter is implemented by the parameters of NewParameters. It is kept out of the
+// Parameters interface so custom implementations of Parameters keep working.
+type paramGetter interface {
+	Get(string) string
+}
+
+// paramValue returns the first value set for the key, or an empty string when the key is
+// not set or the parameters cannot be read
+func paramValue(p Parameters, key string) string {
+	if g, ok := p.(paramGetter); ok {
+		return g.Get(key)
+	}
+	return ""
+}
+
 type parameters struct {
 	keys   []string
 	values [][]string
@@ -47,6 +63,15 @@ func (p *parameters) Set(key string, value ...string) {
 	}
 }
 
+// Get returns the first value set for the key, or an empty
+// string when the key is not set.
+func (p *parameters) Get(key string) string {
+	if n := pos(p.keys, key); n > -1 && len(p.values[n]) > 0 {
+		return p.values[n][0]
+	}
+	return ""
+}
+
 // NewParameters returns a new parameter bag
 func NewParameters(initialLength ...int) Parameters {
 	i := 0
@@ -67,3 +92,9 @@ func pos(list []string, s string) int {
 	}
 	return -1
 }
+
+// encodeList formats values as the escaped JSON array Binance expects for list parameters
+func encodeList(values []string) string {
+	b, _ := json.Marshal(values)
+	return url.QueryEscape(string(b))
+}
@@ -1,6 +1,7 @@
 package binance_test
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/jaztec/go-binance"
@@ -8,6 +9,14 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+// customParameters only implements the methods of the Parameters interface
+type customParameters struct {
+	query string
+}
+
+func (c customParameters) Encode() string        { return c.query }
+func (c customParameters) Set(string, ...string) {}
+
 var _ = Describe("Parameters", func() {
 	var p binance.Parameters
 
@@ -26,6 +35,14 @@ var _ = Describe("Parameters", func() {
 			p.Set("mies", "boom")
 			Expect(p.Encode()).To(Equal("mies=boom&aap=noot"))
 		})
+
+		It("should accept custom implementations", func() {
+			ts := testServer("/api/v3/depth", map[string]struct{}{"symbol": {}}, http.StatusOK, []byte("{}"), nil)
+			defer ts.Close()
+
+			_, err := newAPI(ts.URL).Request(http.MethodGet, "/api/v3/depth", customParameters{"symbol=ETHBTC"})
+			Expect(err).To(BeNil())
+		})
 	})
 
 	Context("have some limits on running time", func() {
@@ -1,7 +1,7 @@
 package binance
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
 
 	"github.com/jaztec/go-binance/model"
@@ -9,31 +9,31 @@ import (
 
 const pricesPath = "/api/v3/ticker/price"
 
-func (a *api) TickerPrice(symbol string) ([]model.Price, error) {
-	var q Parameters
-	if symbol != "" {
-		q = NewParameters(1)
-		q.Set("symbol", symbol)
-	}
+func init() {
+	setWeightFunc(http.MethodGet, pricesPath, func(p Parameters) int {
+		if paramValue(p, "symbol") != "" {
+			return 2
+		}
+		return 4
+	})
+}
+
+func (a *api) TickerPrice(symbols ...string) ([]model.Price, error) {
+	return a.TickerPriceContext(context.Background(), symbols...)
+}
 
-	body, err := a.Request(http.MethodGet, pricesPath, q)
+func (a *api) TickerPriceContext(ctx context.Context, symbols ...string) ([]model.Price, error) {
+	q := symbolParams(symbols)
+
+	body, err := a.RequestContext(ctx, http.MethodGet, pricesPath, q)
 	if err != nil {
 		return nil, err
 	}
 
 	var list []model.Price
-	if symbol != "" {
-		var p model.Price
-		err = json.Unmarshal(body, &p)
-		if err != nil {
-			return nil, err
-		}
-		list = []model.Price{p}
-	} else {
-		err = json.Unmarshal(body, &list)
-		if err != nil {
-			return nil, err
-		}
+	err = decodeTickers(body, q, &list)
+	if err != nil {
+		return nil, err
 	}
 
 	return list, nil
@@ -1,20 +1,51 @@
 package binance
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/jaztec/go-binance/model"
 )
 
+type recvWindowKey struct{}
+
+// WithRecvWindow returns a context that sets the recvWindow in milliseconds for the signed
+// requests made with it, overriding APIConfig.RecvWindow.
+func WithRecvWindow(ctx context.Context, recvWindow int64) context.Context {
+	return context.WithValue(ctx, recvWindowKey{}, recvWindow)
+}
+
+func (a *api) recvWindow(ctx context.Context) int64 {
+	if rw, ok := ctx.Value(recvWindowKey{}).(int64); ok {
+		return rw
+	}
+	return a.cfg.RecvWindow
+}
+
+type waitOnRateLimitKey struct{}
+
+// WithWaitOnRateLimit returns a context that makes the requests made with it block until the
+// rate limit window resets when they would exceed the limits, as if APIConfig.WaitOnRateLimit
+// was set.
+func WithWaitOnRateLimit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, waitOnRateLimitKey{}, true)
+}
+
+func (a *api) waitOnRateLimit(ctx context.Context) bool {
+	if wait, ok := ctx.Value(waitOnRateLimitKey{}).(bool); ok {
+		return wait
+	}
+	return a.cfg.WaitOnRateLimit
+}
+
 var (
 	signatureRequired = make(map[string]struct{})
 	signatureMut      = sync.Mutex{}
@@ -35,53 +66,81 @@ func requiresSignature(path string) bool {
 	return ok
 }
 
-func generateSignature(secret, path string) string {
-	h := hmac.New(sha256.New, []byte(secret))
-	h.Write([]byte(path))
+var (
+	apiKeyRequired = make(map[string]struct{})
+	apiKeyMut      = sync.Mutex{}
+)
+
+// requireAPIKey registers paths that are not signed but refuse calls without an API key
+func requireAPIKey(paths ...string) {
+	apiKeyMut.Lock()
+	defer apiKeyMut.Unlock()
+	for _, p := range paths {
+		apiKeyRequired[p] = struct{}{}
+	}
+}
 
-	return hex.EncodeToString(h.Sum(nil))
+func requiresAPIKey(path string) bool {
+	apiKeyMut.Lock()
+	defer apiKeyMut.Unlock()
+	_, ok := apiKeyRequired[path]
+	return ok
 }
 
 func (a *api) client() *http.Client {
-	c := &http.Client{
-		Transport:     nil,
-		CheckRedirect: nil,
-		Jar:           nil,
-		Timeout:       0,
-	}
-	return c
+	return a.httpClient
 }
 
-func (a *api) request(method string, path string, query Parameters) (*http.Request, error) {
-	var sig string
+func (a *api) request(ctx context.Context, method string, path string, query Parameters) (*http.Request, error) {
 	var qS string
+	if a.cfg.Key == "" && requiresAPIKey(path) {
+		return nil, NoAPIKeyProvided
+	}
+	signed := requiresSignature(path)
+	if signed {
+		if query == nil {
+			query = NewParameters(1)
+		}
+		if rw := a.recvWindow(ctx); rw > 0 && paramValue(query, "recvWindow") == "" {
+			query.Set("recvWindow", strconv.FormatInt(rw, 10))
+		}
+		query.Set("timestamp", a.timestamp())
+	}
 	if query != nil {
 		qS = query.Encode()
 	}
-	if requiresSignature(path) {
-		sig = generateSignature(a.cfg.Secret, qS)
+	if signed {
+		sig, err := a.signer.Sign([]byte(qS))
+		if err != nil {
+			return nil, fmt.Errorf("signing request: %w", err)
+		}
 		qS += "&signature=" + sig
 	}
 
 	var body io.Reader
 	switch method {
-	case http.MethodGet:
+	case http.MethodGet, http.MethodDelete:
 		if qS != "" {
 			path += "?" + qS
 		}
-	case http.MethodPost:
-		body = strings.NewReader(qS)
+	case http.MethodPost, http.MethodPut:
+		if qS != "" {
+			body = strings.NewReader(qS)
+		}
 	}
 
 	fullURL := a.cfg.BaseURI + path
 
 	_ = a.logger.Log("calling", fmt.Sprintf("%s %s", method, fullURL), "params", qS)
 
-	r, err := http.NewRequest(method, fullURL, body)
+	r, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
 	r.Header.Set(APIKeyHeaderName, a.cfg.Key)
+	if body != nil {
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
 
 	return r, nil
 }
@@ -90,27 +149,72 @@ func (a *api) request(method string, path string, query Parameters) (*http.Reque
 // and block any requests for the period defined by the API when in violation. The function
 // will return the raw body of the result on success or an error on failure.
 func (a *api) Request(method, path string, params Parameters) ([]byte, error) {
-	if !a.checker.allowed {
+	return a.RequestContext(context.Background(), method, path, params)
+}
+
+// RequestContext behaves like Request but will abort the call when the provided
+// context is canceled or its deadline expires. In that case ctx.Err() is returned.
+func (a *api) RequestContext(ctx context.Context, method, path string, params Parameters) ([]byte, error) {
+	attempts := 1
+	if isRetrySafe(method, path) && a.cfg.Retry.MaxAttempts > 1 {
+		attempts = a.cfg.Retry.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		body, err := a.do(ctx, method, path, params)
+		if err == nil || attempt >= attempts || !IsRetryable(err) {
+			return body, err
+		}
+
+		// honor the Retry-After period Binance has set
+		delay := a.cfg.Retry.backoff(attempt)
+		if d := time.Until(a.checker.banned()); d > delay {
+			delay = d
+		}
+		_ = a.logger.Log("retrying", fmt.Sprintf("%s %s", method, path), "attempt", attempt, "delay", delay, "error", err)
+		if err := wait(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// do performs a single call to the Binance API
+func (a *api) do(ctx context.Context, method, path string, params Parameters) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !a.checker.allowed() {
 		return nil, AtTimeout
 	}
+	if err := a.checker.reserve(ctx, requestWeight(method, path, params), orderCount(method, path), a.waitOnRateLimit(ctx)); err != nil {
+		return nil, err
+	}
 
-	req, err := a.request(method, path, params)
+	req, err := a.request(ctx, method, path, params)
 	if err != nil {
 		return nil, err
 	}
 
 	res, err := a.client().Do(req)
 	if err != nil {
+		// report a canceled or expired context as is instead of the wrapped url.Error
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, err
 	}
 	defer res.Body.Close()
 
+	a.checker.update(res.Header)
 	if err := a.checker.checkResponse(res); err != nil {
 		return nil, err
 	}
 
 	resBody, err := ioutil.ReadAll(res.Body)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, err
 	}
 
@@ -118,9 +222,9 @@ func (a *api) Request(method, path string, params Parameters) ([]byte, error) {
 		var resErr model.Error
 		err = json.Unmarshal(resBody, &resErr)
 		if err != nil {
-			return nil, err
+			return nil, APIError{msg: fmt.Sprintf("unexpected response status %d", res.StatusCode), status: res.StatusCode}
 		}
-		return nil, APIError{err: &resErr}
+		return nil, APIError{status: res.StatusCode, err: &resErr}
 	}
 
 	return resBody, nil
@@ -0,0 +1,82 @@
+package binance
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+)
+
+var (
+	retrySafeEndpoints = make(map[string]struct{})
+	retrySafeMut       = sync.Mutex{}
+)
+
+// RetryPolicy configures how failed calls are retried. Only GET calls and endpoints
+// that are explicitly marked safe are retried, placing orders never is.
+type RetryPolicy struct {
+	// MaxAttempts including the first call, values below 2 disable retrying
+	MaxAttempts int
+	// InitialBackoff before the first retry, doubled on every next attempt. Defaults to 100ms
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff between two attempts. Defaults to 5s
+	MaxBackoff time.Duration
+}
+
+// backoff returns the exponential backoff with jitter to wait after the given attempt
+func (rp RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max := rp.InitialBackoff, rp.MaxBackoff
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+	d := initial
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	// wait at least half of the backoff and add a random part for the other half
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// retrySafe marks non GET endpoints that can be called again without side effects
+func retrySafe(method string, paths ...string) {
+	retrySafeMut.Lock()
+	defer retrySafeMut.Unlock()
+	for _, p := range paths {
+		retrySafeEndpoints[endpointKey(method, p)] = struct{}{}
+	}
+}
+
+func isRetrySafe(method, path string) bool {
+	if method == http.MethodGet {
+		return true
+	}
+	retrySafeMut.Lock()
+	defer retrySafeMut.Unlock()
+	_, ok := retrySafeEndpoints[endpointKey(method, path)]
+	return ok
+}
+
+// wait blocks for the duration or until the context is done
+func wait(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
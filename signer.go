@@ -0,0 +1,112 @@
+package binance
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Signer creates the signature Binance requires on signed endpoints
+type Signer interface {
+	// Sign returns the signature of the payload, encoded to be used as a query value
+	Sign(payload []byte) (string, error)
+}
+
+// HMACSigner signs payloads with HMAC SHA256 using the secret attached to the API key
+type HMACSigner struct {
+	Secret string
+}
+
+// Sign returns the hex encoded HMAC SHA256 of the payload
+func (s HMACSigner) Sign(payload []byte) (string, error) {
+	h := hmac.New(sha256.New, []byte(s.Secret))
+	h.Write(payload)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// RSASigner signs payloads with RSASSA-PKCS1-v1_5 using SHA256, the private key
+// never leaves the machine running the client
+type RSASigner struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSASigner returns a RSASigner for the PEM encoded PKCS#1 or PKCS#8 private key
+func NewRSASigner(pemKey []byte) (*RSASigner, error) {
+	der, err := decodePEM(pemKey)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return &RSASigner{key: key}, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing RSA private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected a RSA private key, got %T", key)
+	}
+	return &RSASigner{key: rsaKey}, nil
+}
+
+// Sign returns the base64 encoded signature of the payload
+func (s *RSASigner) Sign(payload []byte) (string, error) {
+	hashed := sha256.Sum256(payload)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return url.QueryEscape(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+// Ed25519Signer signs payloads using an Ed25519 key, the private key never
+// leaves the machine running the client
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer returns a Ed25519Signer for the PEM encoded PKCS#8 private key
+func NewEd25519Signer(pemKey []byte) (*Ed25519Signer, error) {
+	der, err := decodePEM(pemKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Ed25519 private key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected a Ed25519 private key, got %T", key)
+	}
+	return &Ed25519Signer{key: edKey}, nil
+}
+
+// Sign returns the base64 encoded signature of the payload
+func (s *Ed25519Signer) Sign(payload []byte) (string, error) {
+	sig := ed25519.Sign(s.key, payload)
+	return url.QueryEscape(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+func decodePEM(pemKey []byte) ([]byte, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errors.New("no PEM data found in key")
+	}
+	if _, ok := block.Headers["Proc-Type"]; ok {
+		return nil, errors.New("encrypted PEM keys are not supported")
+	}
+	return block.Bytes, nil
+}
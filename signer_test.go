@@ -0,0 +1,100 @@
+package binance_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance"
+)
+
+// signedServer returns a server that passes the payload and decoded signature of a call to verify
+func signedServer(verify func(payload, signature []byte)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer GinkgoRecover()
+		parts := strings.SplitN(r.URL.RawQuery, "&signature=", 2)
+		Expect(parts).To(HaveLen(2))
+		encoded, err := url.QueryUnescape(parts[1])
+		Expect(err).To(BeNil())
+		sig, err := base64.StdEncoding.DecodeString(encoded)
+		Expect(err).To(BeNil())
+		verify([]byte(parts[0]), sig)
+		_, _ = w.Write(loadFixture("account_data"))
+	}))
+}
+
+var _ = Describe("Signer", func() {
+	It("should sign requests with an Ed25519 key", func() {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+		der, err := x509.MarshalPKCS8PrivateKey(priv)
+		Expect(err).To(BeNil())
+
+		signer, err := binance.NewEd25519Signer(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		Expect(err).To(BeNil())
+
+		ts := signedServer(func(payload, signature []byte) {
+			Expect(ed25519.Verify(pub, payload, signature)).To(BeTrue())
+		})
+		defer ts.Close()
+
+		a, err := binance.NewAPICaller(binance.APIConfig{Key: apiKey, Signer: signer, BaseURI: ts.URL})
+		Expect(err).To(BeNil())
+		_, err = a.Account()
+		Expect(err).To(BeNil())
+	})
+
+	It("should sign requests with a RSA key", func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).To(BeNil())
+
+		signer, err := binance.NewRSASigner(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}))
+		Expect(err).To(BeNil())
+
+		ts := signedServer(func(payload, signature []byte) {
+			hashed := sha256.Sum256(payload)
+			Expect(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], signature)).To(BeNil())
+		})
+		defer ts.Close()
+
+		a, err := binance.NewAPICaller(binance.APIConfig{Key: apiKey, Signer: signer, BaseURI: ts.URL})
+		Expect(err).To(BeNil())
+		_, err = a.Account()
+		Expect(err).To(BeNil())
+	})
+
+	It("should refuse keys of the wrong type", func() {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+		der, err := x509.MarshalPKCS8PrivateKey(priv)
+		Expect(err).To(BeNil())
+
+		_, err = binance.NewRSASigner(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		Expect(err).ToNot(BeNil())
+		_, err = binance.NewEd25519Signer([]byte("not a key"))
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should sign with HMAC SHA256 by default", func() {
+		// the example of the Binance API documentation
+		signer := binance.HMACSigner{Secret: "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j"}
+		sig, err := signer.Sign([]byte("symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559"))
+		Expect(err).To(BeNil())
+		Expect(sig).To(Equal("c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71"))
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -29,9 +30,49 @@ const (
 	Unsubscribe MessageType = "UNSUBSCRIBE"
 )
 
-type subscriberMap map[string][]chan model.StreamData
+type subscriberMap map[string][]*subscriber
 type channelList []string
 
+// subscriber receives the data of the channels it subscribed to. It is closed by closing done
+// first, so a send in progress is abandoned before the channel is closed.
+type subscriber struct {
+	reads chan model.StreamData
+	done  chan struct{}
+	mut   sync.Mutex
+	once  sync.Once
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{
+		reads: make(chan model.StreamData, 5),
+		done:  make(chan struct{}),
+	}
+}
+
+// send blocks until the subscriber received the data or is closed
+func (sub *subscriber) send(sd model.StreamData) {
+	sub.mut.Lock()
+	defer sub.mut.Unlock()
+	select {
+	case <-sub.done:
+		return
+	default:
+	}
+	select {
+	case sub.reads <- sd:
+	case <-sub.done:
+	}
+}
+
+func (sub *subscriber) close() {
+	sub.once.Do(func() {
+		close(sub.done)
+		sub.mut.Lock()
+		defer sub.mut.Unlock()
+		close(sub.reads)
+	})
+}
+
 func (cl channelList) Len() int           { return len(cl) }
 func (cl channelList) Swap(i, j int)      { cl[i], cl[j] = cl[j], cl[i] }
 func (cl channelList) Less(i, j int) bool { return cl[i] < cl[j] }
@@ -59,70 +100,132 @@ type SubscribeMessage struct {
 }
 
 type stream struct {
-	id          string
-	conn        *websocket.Conn
+	id     string
+	conn   *websocket.Conn
+	writes chan []byte
+	lastID uint64
+	logger Logger
+	closed chan struct{}
+	// stopped is closed when the writePump exits
+	stopped chan struct{}
+
+	mut         sync.Mutex
 	channels    channelList
-	writes      chan []byte
-	lastID      uint64
 	subscribers subscriberMap
-	logger      Logger
-	closed      chan struct{}
 }
 
 func (s *stream) unsubscribe(params []string) error {
-	atomic.AddUint64(&s.lastID, 1)
-
 	msg := SubscribeMessage{
 		Method: Unsubscribe,
 		Params: params,
-		ID:     s.lastID,
+		ID:     atomic.AddUint64(&s.lastID, 1),
 	}
 
 	b, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
-	s.writes <- b
+	s.write(b)
 
+	var subs []*subscriber
+	s.mut.Lock()
 	for _, param := range params {
 		if list, ok := s.subscribers[param]; ok {
-			for _, ch := range list {
-				close(ch)
-			}
+			subs = append(subs, list...)
 			delete(s.subscribers, param)
 		}
-		if n := s.channels.IndexOf(param); n > -1 {
+		for n := s.channels.IndexOf(param); n > -1; n = s.channels.IndexOf(param) {
 			// remove channel but keep order intact
 			s.channels = append(s.channels[:n], s.channels[n+1:]...)
 		}
 	}
+	s.mut.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
 
 	return nil
 }
 
+// remove closes a single subscriber of the channel, the channel is only unsubscribed from
+// when no other subscribers are left
+func (s *stream) remove(param string, reads <-chan model.StreamData) error {
+	var sub *subscriber
+	s.mut.Lock()
+	list := s.subscribers[param]
+	for i, el := range list {
+		if el.reads == reads {
+			sub = el
+			list = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+	if sub == nil {
+		s.mut.Unlock()
+		return nil
+	}
+	if len(list) > 0 {
+		s.subscribers[param] = list
+	} else {
+		delete(s.subscribers, param)
+	}
+	if n := s.channels.IndexOf(param); n > -1 {
+		s.channels = append(s.channels[:n], s.channels[n+1:]...)
+	}
+	s.mut.Unlock()
+
+	sub.close()
+	if len(list) > 0 {
+		return nil
+	}
+
+	msg := SubscribeMessage{
+		Method: Unsubscribe,
+		Params: []string{param},
+		ID:     atomic.AddUint64(&s.lastID, 1),
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.write(b)
+	return nil
+}
+
+// write hands the message to the writePump, it is dropped when the writePump has stopped
+func (s *stream) write(b []byte) {
+	select {
+	case s.writes <- b:
+	case <-s.stopped:
+	}
+}
+
 func (s *stream) subscribe(params []string) (<-chan model.StreamData, error) {
-	atomic.AddUint64(&s.lastID, 1)
+	id := atomic.AddUint64(&s.lastID, 1)
 	_ = s.logger.Log("subscribe", strings.Join(params, ", "))
 
 	newParams := make([]string, 0, len(params))
-	reads := make(chan model.StreamData, 5)
+	sub := newSubscriber()
+	s.mut.Lock()
 	for _, param := range params {
 		if _, ok := s.subscribers[param]; !ok {
-			s.subscribers[param] = make([]chan model.StreamData, 0, 1)
+			s.subscribers[param] = make([]*subscriber, 0, 1)
 			newParams = append(newParams, param)
 		}
-		s.subscribers[param] = append(s.subscribers[param], reads)
+		s.subscribers[param] = append(s.subscribers[param], sub)
 	}
 
 	// keep track of channels we connect on
 	s.channels = append(s.channels, params...)
 	sort.Sort(s.channels)
+	s.mut.Unlock()
 
 	if len(newParams) > 0 {
 		msg := SubscribeMessage{
 			Method: Subscribe,
 			Params: newParams,
-			ID:     s.lastID,
+			ID:     id,
 		}
 
 		b, err := json.Marshal(msg)
@@ -132,7 +235,7 @@ func (s *stream) subscribe(params []string) (<-chan model.StreamData, error) {
 		s.writes <- b
 	}
 
-	return reads, nil
+	return sub.reads, nil
 }
 
 func (s *stream) readPump() {
@@ -156,12 +259,11 @@ func (s *stream) readPump() {
 			continue
 		}
 
-		list, ok := s.subscribers[sd.Stream]
-		if !ok {
-			continue
-		}
-		for _, ch := range list {
-			ch <- sd
+		s.mut.Lock()
+		list := s.subscribers[sd.Stream]
+		s.mut.Unlock()
+		for _, sub := range list {
+			sub.send(sd)
 		}
 	}
 }
@@ -169,6 +271,7 @@ func (s *stream) readPump() {
 func (s *stream) writePump(ctx context.Context) {
 	t := time.NewTicker(pongPeriod)
 	defer t.Stop()
+	defer close(s.stopped)
 
 	for {
 		select {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/dchest/uniuri"
@@ -26,9 +27,12 @@ type StreamCaller interface {
 	// UserDataStream updates when user account changes have occurred
 	UserDataStream(ctx context.Context) (<-chan model.UserAccountUpdate, error)
 	// Kline data for a list of tokens
-	Kline(ctx context.Context, symbols []string, interval string) (<-chan model.KlineData, error)
+	Kline(ctx context.Context, symbols []string, interval model.KlineInterval) (<-chan model.KlineData, error)
 	// TickerArr changes to prices from the ticker API
 	TickerArr(ctx context.Context) (chan []model.Ticker, error)
+	// OrderBook of a symbol maintained locally from a depth snapshot and the diff depth stream
+	// until the context is done
+	OrderBook(ctx context.Context, symbol string, params OrderBookParams) (*OrderBook, error)
 }
 
 type streamer struct {
@@ -58,7 +62,7 @@ func (s *streamer) keepAlive(ctx context.Context, path string, interval time.Dur
 		for {
 			select {
 			case <-tC:
-				_, _ = s.api.Request(http.MethodPut, path, nil)
+				_, _ = s.api.RequestContext(ctx, http.MethodPut, path, nil)
 			case <-ctx.Done():
 				return
 			}
@@ -89,6 +93,7 @@ func (s *streamer) stream(ctx context.Context) (*stream, error) {
 		subscribers: make(subscriberMap),
 		logger:      s.logger,
 		closed:      make(chan struct{}),
+		stopped:     make(chan struct{}),
 	}
 	s.streams = append(s.streams, st)
 
@@ -122,21 +127,26 @@ func (s *streamer) resetStream(ctx context.Context, st *stream) error {
 	}
 
 	// copy values from last stream to the new one
-	nst.lastID = st.lastID + 1
+	atomic.StoreUint64(&nst.lastID, atomic.LoadUint64(&st.lastID)+1)
+	st.mut.Lock()
+	nst.mut.Lock()
 	for k, v := range st.subscribers {
 		nst.subscribers[k] = v
 	}
 	nst.channels = make([]string, len(st.channels))
 	copy(nst.channels, st.channels)
+	channels := append([]string(nil), nst.channels...)
+	st.mut.Unlock()
+	nst.mut.Unlock()
 
-	_ = s.logger.Log("resetting", strings.Join(nst.channels, ","))
+	_ = s.logger.Log("resetting", strings.Join(channels, ","))
 	// subscribe to the channels the old stream was subscribed to
 	// we purposely don't use subscribe method to keep subscriber map intact
-	if len(nst.channels) > 0 {
+	if len(channels) > 0 {
 		msg := SubscribeMessage{
 			Method: Subscribe,
-			Params: nst.channels,
-			ID:     nst.lastID,
+			Params: channels,
+			ID:     atomic.LoadUint64(&nst.lastID),
 		}
 
 		b, err := json.Marshal(msg)
@@ -149,6 +159,17 @@ func (s *streamer) resetStream(ctx context.Context, st *stream) error {
 	return nil
 }
 
+// removeSubscriber closes the subscriber reading from reads, the channel is unsubscribed from
+// when it was the last subscriber
+func (s *streamer) removeSubscriber(param string, reads <-chan model.StreamData) error {
+	for _, st := range s.streams {
+		if err := st.remove(param, reads); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *streamer) removeStream(id string) {
 	for i, st := range s.streams {
 		if st.id == id {
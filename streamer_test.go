@@ -3,6 +3,7 @@ package binance_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -110,7 +111,7 @@ var _ = Describe("Stream", func() {
 			It("should call Kline function", func() {
 				ctx, cancelFn := context.WithCancel(context.Background())
 				defer cancelFn()
-				_, err := a.Stream().(binance.StreamCaller).Kline(ctx, []string{"ETHBTC"}, "5m")
+				_, err := a.Stream().(binance.StreamCaller).Kline(ctx, []string{"ETHBTC"}, model.FiveMinutes)
 				Expect(err).To(BeNil())
 			})
 
@@ -136,7 +137,7 @@ var _ = Describe("Stream", func() {
 				defer cancelFn()
 				var err error
 
-				_, err = a.Stream().(binance.StreamCaller).Kline(ctx, []string{"ETHBTC"}, "5m")
+				_, err = a.Stream().(binance.StreamCaller).Kline(ctx, []string{"ETHBTC"}, model.FiveMinutes)
 				Expect(err).To(BeNil())
 				_, err = a.Stream().(binance.StreamCaller).UserDataStream(ctx)
 				Expect(err).To(BeNil())
@@ -154,5 +155,54 @@ var _ = Describe("Stream", func() {
 				}))
 			})
 		})
+
+		It("should decode list status events from the user data stream", func() {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				if r.Header.Get("Connection") != "Upgrade" {
+					_, _ = w.Write([]byte(`{"listenKey":"userDataStreamAllowed"}`))
+					return
+				}
+				c, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+				Expect(err).To(BeNil())
+				defer c.Close()
+
+				_, _, err = c.ReadMessage()
+				Expect(err).To(BeNil())
+				data := fmt.Sprintf(`{"stream":"userDataStreamAllowed","data":%s}`, loadFixture("list_status_event_data"))
+				Expect(c.WriteMessage(websocket.TextMessage, []byte(data))).To(Succeed())
+				for {
+					if _, _, err := c.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}))
+			defer ts.Close()
+
+			a, err := binance.NewAPICaller(binance.APIConfig{
+				Key:           apiKey,
+				Secret:        apiSecret,
+				BaseURI:       ts.URL,
+				BaseStreamURI: strings.ReplaceAll(ts.URL, "http", "ws"),
+			})
+			Expect(err).To(BeNil())
+
+			ctx, cancelFn := context.WithCancel(context.Background())
+			defer cancelFn()
+			ch, err := a.StreamCaller().UserDataStream(ctx)
+			Expect(err).To(BeNil())
+
+			var update model.UserAccountUpdate
+			Eventually(ch).Should(Receive(&update))
+			Expect(update.Type()).To(Equal(model.ListStatusType))
+			ls, ok := update.(model.ListStatus)
+			Expect(ok).To(BeTrue())
+			Expect(ls.Symbol).To(Equal("ETHBTC"))
+			Expect(ls.OrderListID).To(Equal(2))
+			Expect(ls.ContingencyType).To(Equal("OCO"))
+			Expect(ls.ListOrderStatus).To(Equal("EXECUTING"))
+			Expect(ls.Orders).To(HaveLen(2))
+			Expect(ls.Orders[1].OrderID).To(Equal(18))
+		})
 	})
 })
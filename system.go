@@ -0,0 +1,93 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+const (
+	pingPath         = "/api/v3/ping"
+	systemStatusPath = "/sapi/v1/system/status"
+)
+
+func init() {
+	setWeight(http.MethodGet, pingPath, 1)
+	// the SAPI endpoints are counted against their own limits
+	setWeight(http.MethodGet, systemStatusPath, 0)
+}
+
+// Health reports the connectivity with the Binance REST API
+type Health struct {
+	// Reachable when the API answered a ping
+	Reachable bool
+	// Latency of the ping round trip
+	Latency time.Duration
+	// ClockOffset is how far the Binance servers are ahead of the local clock
+	ClockOffset time.Duration
+	// Maintenance when Binance reports the system is in maintenance
+	Maintenance bool
+	// UsedWeight and WeightLimit of the shortest request weight window
+	UsedWeight  int
+	WeightLimit int
+}
+
+func (a *api) Ping() error {
+	return a.PingContext(context.Background())
+}
+
+func (a *api) PingContext(ctx context.Context) error {
+	_, err := a.RequestContext(ctx, http.MethodGet, pingPath, nil)
+	return err
+}
+
+func (a *api) SystemStatus() (model.SystemStatus, error) {
+	return a.SystemStatusContext(context.Background())
+}
+
+func (a *api) SystemStatusContext(ctx context.Context) (ss model.SystemStatus, err error) {
+	body, err := a.RequestContext(ctx, http.MethodGet, systemStatusPath, nil)
+	if err != nil {
+		return ss, err
+	}
+
+	err = json.Unmarshal(body, &ss)
+	if err != nil {
+		return ss, fmt.Errorf("encountered error while unmarshaling '%s' into model.SystemStatus", body)
+	}
+
+	return ss, nil
+}
+
+// HealthCheck pings the API, measures the clock offset without applying it and retrieves the
+// system status. The health gathered up to the first failing call is returned with its error.
+func (a *api) HealthCheck(ctx context.Context) (h Health, err error) {
+	defer func() {
+		h.UsedWeight, h.WeightLimit = a.checker.usage(model.RequestWeight)
+	}()
+
+	start := time.Now()
+	if err = a.PingContext(ctx); err != nil {
+		return h, err
+	}
+	h.Reachable = true
+	h.Latency = time.Since(start)
+
+	offset, err := a.measureTimeOffset(ctx)
+	if err != nil {
+		return h, err
+	}
+	h.ClockOffset = time.Duration(offset) * time.Millisecond
+
+	ss, err := a.SystemStatusContext(ctx)
+	if err != nil {
+		return h, err
+	}
+	h.Maintenance = ss.Maintenance()
+
+	return h, nil
+}
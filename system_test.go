@@ -0,0 +1,61 @@
+package binance_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("System", func() {
+	It("should report the health of the API", func() {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-MBX-USED-WEIGHT-1M", "42")
+			switch r.URL.Path {
+			case "/api/v3/ping":
+				_, _ = w.Write([]byte("{}"))
+			case "/api/v3/time":
+				now := time.Now().Add(5*time.Second).UnixNano() / int64(time.Millisecond)
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"serverTime":%d}`, now)))
+			case "/sapi/v1/system/status":
+				_, _ = w.Write([]byte(`{"status":1,"msg":"system maintenance"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer ts.Close()
+
+		a := newAPI(ts.URL)
+		h, err := a.HealthCheck(context.Background())
+		Expect(err).To(BeNil())
+		Expect(h.Reachable).To(BeTrue())
+		Expect(h.Latency).To(BeNumerically(">", 0))
+		Expect(h.ClockOffset).To(BeNumerically("~", 5*time.Second, time.Second))
+		Expect(h.Maintenance).To(BeTrue())
+		Expect(h.UsedWeight).To(Equal(42))
+		Expect(h.WeightLimit).To(Equal(1200))
+		// the health check does not change the offset used for signing
+		Expect(a.TimeOffset()).To(Equal(time.Duration(0)))
+	})
+
+	It("should report an unreachable API", func() {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		ts.Close()
+
+		h, err := newAPI(ts.URL).HealthCheck(context.Background())
+		Expect(err).ToNot(BeNil())
+		Expect(h.Reachable).To(BeFalse())
+	})
+
+	It("should ping the API", func() {
+		ts := testServer("/api/v3/ping", nil, http.StatusOK, []byte("{}"), nil)
+		defer ts.Close()
+
+		Expect(newAPI(ts.URL).Ping()).To(Succeed())
+	})
+})
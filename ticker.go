@@ -0,0 +1,142 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+const (
+	bookTickerPath    = "/api/v3/ticker/bookTicker"
+	rollingTickerPath = "/api/v3/ticker"
+
+	// maxRollingTickerSymbols is the amount of symbols the rolling window ticker accepts per call
+	maxRollingTickerSymbols = 100
+)
+
+// windowSizePattern matches the window sizes of the rolling window ticker, 1-59m, 1-23h or 1-7d
+var windowSizePattern = regexp.MustCompile(`^([1-9]|[1-5][0-9])m$|^([1-9]|1[0-9]|2[0-3])h$|^[1-7]d$`)
+
+func init() {
+	setWeightFunc(http.MethodGet, bookTickerPath, func(p Parameters) int {
+		if paramValue(p, "symbol") != "" {
+			return 2
+		}
+		return 4
+	})
+	setWeightFunc(http.MethodGet, rollingTickerPath, func(p Parameters) int {
+		w := 4 * symbolCount(p)
+		if w > 200 {
+			return 200
+		}
+		return w
+	})
+}
+
+// symbolParams sets a single symbol as symbol and multiple as the symbols list, empty symbols
+// are ignored. Nil is returned when no symbols remain.
+func symbolParams(symbols []string) Parameters {
+	list := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if s != "" {
+			list = append(list, s)
+		}
+	}
+
+	var p Parameters
+	switch len(list) {
+	case 0:
+	case 1:
+		p = NewParameters(2)
+		p.Set("symbol", list[0])
+	default:
+		p = NewParameters(2)
+		p.Set("symbols", encodeList(list))
+	}
+	return p
+}
+
+// symbolCount returns the amount of symbols requested by the parameters
+func symbolCount(p Parameters) int {
+	if p == nil {
+		return 0
+	}
+	if paramValue(p, "symbol") != "" {
+		return 1
+	}
+	raw, err := url.QueryUnescape(paramValue(p, "symbols"))
+	if err != nil {
+		return 0
+	}
+	var list []string
+	_ = json.Unmarshal([]byte(raw), &list)
+	return len(list)
+}
+
+// decodeTickers decodes the single object returned for one symbol or the list returned
+// otherwise into list, a pointer to a slice
+func decodeTickers(body []byte, p Parameters, list interface{}) error {
+	if paramValue(p, "symbol") == "" {
+		return json.Unmarshal(body, list)
+	}
+	// wrap the single object so it decodes into the slice
+	return json.Unmarshal(append(append([]byte("["), body...), ']'), list)
+}
+
+func (a *api) BookTicker(symbols ...string) ([]model.BookTicker, error) {
+	return a.BookTickerContext(context.Background(), symbols...)
+}
+
+func (a *api) BookTickerContext(ctx context.Context, symbols ...string) (bt []model.BookTicker, err error) {
+	q := symbolParams(symbols)
+
+	body, err := a.RequestContext(ctx, http.MethodGet, bookTickerPath, q)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodeTickers(body, q, &bt)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error while unmarshaling '%s' into model.BookTicker", body)
+	}
+
+	return bt, nil
+}
+
+func (a *api) RollingTicker(windowSize string, symbols ...string) ([]model.TickerStatistics, error) {
+	return a.RollingTickerContext(context.Background(), windowSize, symbols...)
+}
+
+func (a *api) RollingTickerContext(ctx context.Context, windowSize string, symbols ...string) (ts []model.TickerStatistics, err error) {
+	q := symbolParams(symbols)
+	if q == nil {
+		return nil, NoSymbolProvided
+	}
+	if n := symbolCount(q); n > maxRollingTickerSymbols {
+		return nil, fmt.Errorf("rolling window ticker accepts at most %d symbols, got %d", maxRollingTickerSymbols, n)
+	}
+	if windowSize != "" {
+		if !windowSizePattern.MatchString(windowSize) {
+			return nil, errors.New("windowSize must be 1-59m, 1-23h or 1-7d")
+		}
+		q.Set("windowSize", windowSize)
+	}
+
+	body, err := a.RequestContext(ctx, http.MethodGet, rollingTickerPath, q)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodeTickers(body, q, &ts)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error while unmarshaling '%s' into model.TickerStatistics", body)
+	}
+
+	return ts, nil
+}
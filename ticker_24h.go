@@ -1,7 +1,7 @@
 package binance
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
 
 	"github.com/jaztec/go-binance/model"
@@ -9,30 +9,33 @@ import (
 
 const ticker24hPath = "/api/v3/ticker/24hr"
 
-func (a *api) Ticker24h(symbol string) (ts []model.TickerStatistics, err error) {
-	var q Parameters
-	if symbol != "" {
-		q = NewParameters(1)
-		q.Set("symbol", symbol)
-	}
+func init() {
+	setWeightFunc(http.MethodGet, ticker24hPath, func(p Parameters) int {
+		switch n := symbolCount(p); {
+		case n == 0 || n > 100:
+			return 80
+		case n > 20:
+			return 40
+		}
+		return 2
+	})
+}
+
+func (a *api) Ticker24h(symbols ...string) (ts []model.TickerStatistics, err error) {
+	return a.Ticker24hContext(context.Background(), symbols...)
+}
 
-	body, err := a.Request(http.MethodGet, ticker24hPath, q)
+func (a *api) Ticker24hContext(ctx context.Context, symbols ...string) (ts []model.TickerStatistics, err error) {
+	q := symbolParams(symbols)
+
+	body, err := a.RequestContext(ctx, http.MethodGet, ticker24hPath, q)
 	if err != nil {
 		return nil, err
 	}
 
-	if symbol != "" {
-		var p model.TickerStatistics
-		err = json.Unmarshal(body, &p)
-		if err != nil {
-			return nil, err
-		}
-		ts = append(ts, p)
-	} else {
-		err = json.Unmarshal(body, &ts)
-		if err != nil {
-			return nil, err
-		}
+	err = decodeTickers(body, q, &ts)
+	if err != nil {
+		return nil, err
 	}
 
 	return
@@ -0,0 +1,84 @@
+package binance_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("Ticker", func() {
+	var (
+		ts    *httptest.Server
+		query url.Values
+		reply string
+	)
+
+	BeforeEach(func() {
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			// 12 weight left in the current window
+			w.Header().Set("X-MBX-USED-WEIGHT-1M", "1188")
+			_, _ = w.Write([]byte(reply))
+		}))
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	It("should query a list of symbols as JSON array", func() {
+		reply = `[{"symbol":"LTCBTC","price":"4.00000200"},{"symbol":"ETHBTC","price":"0.07946600"}]`
+		p, err := newAPI(ts.URL).TickerPrice("LTCBTC", "ETHBTC")
+		Expect(err).To(BeNil())
+		Expect(p).To(HaveLen(2))
+		Expect(query.Get("symbols")).To(Equal(`["LTCBTC","ETHBTC"]`))
+		Expect(query.Get("symbol")).To(BeEmpty())
+	})
+
+	It("should decode the single object of one symbol", func() {
+		reply = `{"symbol":"LNCBTC","bidPrice":"4.00000000","bidQty":"431.00000000","askPrice":"4.00000200","askQty":"9.00000000"}`
+		bt, err := newAPI(ts.URL).BookTicker("LNCBTC")
+		Expect(err).To(BeNil())
+		Expect(bt).To(HaveLen(1))
+		Expect(bt[0].BidQty.String()).To(Equal("431.00000000"))
+		Expect(bt[0].AskPrice.String()).To(Equal("4.00000200"))
+		Expect(query.Get("symbol")).To(Equal("LNCBTC"))
+	})
+
+	It("should query the rolling window ticker", func() {
+		reply = `[{"symbol":"BNBBTC","priceChange":"-8.00000000","priceChangePercent":"-88.889","openTime":1677052800000,"closeTime":1677139199999,"count":3}]`
+		ts24, err := newAPI(ts.URL).RollingTicker("7d", "BNBBTC", "ETHBTC")
+		Expect(err).To(BeNil())
+		Expect(ts24[0].PriceChange.String()).To(Equal("-8.00000000"))
+		Expect(ts24[0].Count).To(Equal(3))
+		Expect(query.Get("windowSize")).To(Equal("7d"))
+	})
+
+	It("should validate the rolling window ticker parameters", func() {
+		a := newAPI(ts.URL)
+		_, err := a.RollingTicker("1d")
+		Expect(err).To(Equal(binance.NoSymbolProvided))
+		_, err = a.RollingTicker("60m", "BNBBTC")
+		Expect(err).ToNot(BeNil())
+		_, err = a.RollingTicker("8d", "BNBBTC")
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should account 4 weight per symbol on the rolling window ticker", func() {
+		reply = `[]`
+		a := newAPI(ts.URL)
+		_, err := a.RollingTicker("", "A", "B", "C")
+		Expect(err).To(BeNil())
+
+		_, err = a.RollingTicker("", "A", "B", "C")
+		Expect(err).To(BeNil())
+		_, err = a.RollingTicker("", "A", "B", "C", "D")
+		Expect(err).To(Equal(binance.LimitExceeded))
+	})
+})
@@ -0,0 +1,98 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+const timePath = "/api/v3/time"
+
+func init() {
+	setWeight(http.MethodGet, timePath, 1)
+}
+
+func (a *api) Time() (model.ServerTime, error) {
+	return a.TimeContext(context.Background())
+}
+
+func (a *api) TimeContext(ctx context.Context) (st model.ServerTime, err error) {
+	body, err := a.RequestContext(ctx, http.MethodGet, timePath, nil)
+	if err != nil {
+		return st, err
+	}
+
+	err = json.Unmarshal(body, &st)
+	if err != nil {
+		return st, fmt.Errorf("encountered error while unmarshaling '%s' into model.ServerTime", body)
+	}
+
+	return st, nil
+}
+
+// SyncTime measures the difference between the local clock and the Binance servers. The
+// offset is used for the timestamp of every signed request.
+func (a *api) SyncTime(ctx context.Context) error {
+	offset, err := a.measureTimeOffset(ctx)
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt64(&a.timeOffset, offset)
+	return nil
+}
+
+// measureTimeOffset returns how far the Binance servers are ahead of the local clock in milliseconds
+func (a *api) measureTimeOffset(ctx context.Context) (int64, error) {
+	before := milliseconds(time.Now())
+	st, err := a.TimeContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	after := milliseconds(time.Now())
+
+	// assume the server handled the call halfway the round trip
+	return st.ServerTime - (before+after)/2, nil
+}
+
+// StartTimeSync synchronises the clock offset now and keeps doing so every interval
+// until the context is done.
+func (a *api) StartTimeSync(ctx context.Context, interval time.Duration) error {
+	if err := a.SyncTime(ctx); err != nil {
+		return err
+	}
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				if err := a.SyncTime(ctx); err != nil {
+					_ = a.logger.Log("time", "sync", "error", err.Error())
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// TimeOffset returns how far the Binance servers are ahead of the local clock
+func (a *api) TimeOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&a.timeOffset)) * time.Millisecond
+}
+
+// timestamp returns the corrected current time in milliseconds to use in signed requests
+func (a *api) timestamp() string {
+	return strconv.FormatInt(milliseconds(time.Now())+atomic.LoadInt64(&a.timeOffset), 10)
+}
+
+func milliseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -1,7 +1,9 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -11,15 +13,31 @@ import (
 )
 
 const (
-	myTradesPath = "/api/v3/myTrades"
+	myTradesPath         = "/api/v3/myTrades"
+	tradesPath           = "/api/v3/trades"
+	historicalTradesPath = "/api/v3/historicalTrades"
+	aggTradesPath        = "/api/v3/aggTrades"
+
+	// maxAggTradesWindow is the time allowed between the start and end time of aggregate trades
+	maxAggTradesWindow = int64(time.Hour / time.Millisecond)
 )
 
 func init() {
 	requireSignature(myTradesPath)
+	requireAPIKey(historicalTradesPath)
+	setWeight(http.MethodGet, myTradesPath, 20)
+	setWeight(http.MethodGet, tradesPath, 25)
+	setWeight(http.MethodGet, historicalTradesPath, 25)
+	setWeight(http.MethodGet, aggTradesPath, 2)
 }
 
 // MyTrades returns trades performed by a user in a time window
 func (a *api) MyTrades(symbol string, startTime, endTime int64, limit int) (t []model.UserTrade, err error) {
+	return a.MyTradesContext(context.Background(), symbol, startTime, endTime, limit)
+}
+
+// MyTradesContext returns trades performed by a user in a time window
+func (a *api) MyTradesContext(ctx context.Context, symbol string, startTime, endTime int64, limit int) (t []model.UserTrade, err error) {
 	if symbol == "" {
 		return t, NoSymbolProvided
 	}
@@ -34,9 +52,8 @@ func (a *api) MyTrades(symbol string, startTime, endTime int64, limit int) (t []
 	if limit != 0 {
 		q.Set("limit", strconv.Itoa(limit))
 	}
-	q.Set("timestamp", strconv.FormatInt(time.Now().Unix()*1000, 10))
 
-	body, err := a.Request(http.MethodGet, myTradesPath, q)
+	body, err := a.RequestContext(ctx, http.MethodGet, myTradesPath, q)
 	if err != nil {
 		return t, err
 	}
@@ -48,3 +65,106 @@ func (a *api) MyTrades(symbol string, startTime, endTime int64, limit int) (t []
 
 	return t, nil
 }
+
+// Trades returns the most recent trades of a symbol
+func (a *api) Trades(symbol string, limit int) ([]model.MarketTrade, error) {
+	return a.TradesContext(context.Background(), symbol, limit)
+}
+
+// TradesContext returns the most recent trades of a symbol
+func (a *api) TradesContext(ctx context.Context, symbol string, limit int) (t []model.MarketTrade, err error) {
+	if symbol == "" {
+		return t, NoSymbolProvided
+	}
+	q := NewParameters(2)
+	q.Set("symbol", symbol)
+	if limit != 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	return a.trades(ctx, tradesPath, q)
+}
+
+// HistoricalTrades returns the trades of a symbol starting at trade id fromID
+func (a *api) HistoricalTrades(symbol string, fromID int64, limit int) ([]model.MarketTrade, error) {
+	return a.HistoricalTradesContext(context.Background(), symbol, fromID, limit)
+}
+
+// HistoricalTradesContext returns the trades of a symbol starting at trade id fromID
+func (a *api) HistoricalTradesContext(ctx context.Context, symbol string, fromID int64, limit int) (t []model.MarketTrade, err error) {
+	if symbol == "" {
+		return t, NoSymbolProvided
+	}
+	q := NewParameters(3)
+	q.Set("symbol", symbol)
+	if fromID != 0 {
+		q.Set("fromId", strconv.FormatInt(fromID, 10))
+	}
+	if limit != 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	return a.trades(ctx, historicalTradesPath, q)
+}
+
+func (a *api) trades(ctx context.Context, path string, q Parameters) (t []model.MarketTrade, err error) {
+	body, err := a.RequestContext(ctx, http.MethodGet, path, q)
+	if err != nil {
+		return t, err
+	}
+
+	err = json.Unmarshal(body, &t)
+	if err != nil {
+		return t, fmt.Errorf("encountered error while unmarshaling '%s' into model.MarketTrade", body)
+	}
+
+	return t, nil
+}
+
+// AggTrades returns the aggregate trades of a symbol starting at aggregate trade id fromID or
+// in a time window of less than an hour
+func (a *api) AggTrades(symbol string, fromID, startTime, endTime int64, limit int) ([]model.AggTrade, error) {
+	return a.AggTradesContext(context.Background(), symbol, fromID, startTime, endTime, limit)
+}
+
+// AggTradesContext returns the aggregate trades of a symbol starting at aggregate trade id fromID
+// or in a time window of less than an hour
+func (a *api) AggTradesContext(ctx context.Context, symbol string, fromID, startTime, endTime int64, limit int) (t []model.AggTrade, err error) {
+	if symbol == "" {
+		return t, NoSymbolProvided
+	}
+	if startTime != 0 && endTime != 0 {
+		if endTime < startTime {
+			return t, errors.New("endTime of aggregate trades is before startTime")
+		}
+		if endTime-startTime >= maxAggTradesWindow {
+			return t, errors.New("startTime and endTime of aggregate trades must be less than an hour apart")
+		}
+	}
+	q := NewParameters(5)
+	q.Set("symbol", symbol)
+	if fromID != 0 {
+		q.Set("fromId", strconv.FormatInt(fromID, 10))
+	}
+	if startTime != 0 {
+		q.Set("startTime", strconv.FormatInt(startTime, 10))
+	}
+	if endTime != 0 {
+		q.Set("endTime", strconv.FormatInt(endTime, 10))
+	}
+	if limit != 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	body, err := a.RequestContext(ctx, http.MethodGet, aggTradesPath, q)
+	if err != nil {
+		return t, err
+	}
+
+	err = json.Unmarshal(body, &t)
+	if err != nil {
+		return t, fmt.Errorf("encountered error while unmarshaling '%s' into model.AggTrade", body)
+	}
+
+	return t, nil
+}
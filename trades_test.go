@@ -0,0 +1,80 @@
+package binance_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/jaztec/go-binance"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("Trades", func() {
+	It("should return the recent trades", func() {
+		ts := testServer("/api/v3/trades", map[string]struct{}{
+			"symbol": {},
+			"limit":  {},
+		}, http.StatusOK, loadFixture("trades_data"), nil)
+		defer ts.Close()
+
+		t, err := newAPI(ts.URL).Trades("BNBBTC", 1)
+		Expect(err).To(BeNil())
+		Expect(t).To(HaveLen(1))
+		Expect(t[0].ID).To(Equal(int64(28457)))
+		Expect(t[0].QuoteQty.String()).To(Equal("48.000012"))
+		Expect(t[0].IsBuyerMaker).To(BeTrue())
+	})
+
+	It("should send the API key for historical trades without signing", func() {
+		var key string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal("/api/v3/historicalTrades"))
+			Expect(r.URL.Query().Get("fromId")).To(Equal("28000"))
+			Expect(r.URL.Query().Get("signature")).To(BeEmpty())
+			key = r.Header.Get(binance.APIKeyHeaderName)
+			_, _ = w.Write(loadFixture("trades_data"))
+		}))
+		defer ts.Close()
+
+		t, err := newAPI(ts.URL).HistoricalTrades("BNBBTC", 28000, 0)
+		Expect(err).To(BeNil())
+		Expect(t).To(HaveLen(1))
+		Expect(key).To(Equal(apiKey))
+	})
+
+	It("should refuse historical trades without an API key", func() {
+		a, err := binance.NewAPICaller(binance.APIConfig{BaseURI: "http://mies.mees"})
+		Expect(err).To(BeNil())
+		_, err = a.HistoricalTrades("BNBBTC", 0, 0)
+		Expect(err).To(Equal(binance.NoAPIKeyProvided))
+	})
+
+	It("should return the aggregate trades", func() {
+		ts := testServer("/api/v3/aggTrades", map[string]struct{}{
+			"symbol":    {},
+			"startTime": {},
+			"endTime":   {},
+		}, http.StatusOK, loadFixture("agg_trades_data"), nil)
+		defer ts.Close()
+
+		t, err := newAPI(ts.URL).AggTrades("BNBBTC", 0, 1498793700000, 1498793800000, 0)
+		Expect(err).To(BeNil())
+		Expect(t).To(HaveLen(1))
+		Expect(t[0].ID).To(Equal(int64(26129)))
+		Expect(t[0].Price.String()).To(Equal("0.01633102"))
+		Expect(t[0].Time).To(Equal(int64(1498793709153)))
+		Expect(t[0].IsBuyerMaker).To(BeTrue())
+		Expect(t[0].IsBestMatch).To(BeFalse())
+	})
+
+	It("should refuse an aggregate trades window of an hour or more", func() {
+		a := newAPI("http://mies.mees")
+		_, err := a.AggTrades("BNBBTC", 0, 1498793700000, 1498793700000+3600000, 0)
+		Expect(err).ToNot(BeNil())
+		_, err = a.AggTrades("BNBBTC", 0, 1498793700000, 1498793600000, 0)
+		Expect(err).ToNot(BeNil())
+	})
+})
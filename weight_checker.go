@@ -0,0 +1,325 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jaztec/go-binance/model"
+)
+
+const (
+	usedWeightHeaderPrefix = "X-Mbx-Used-Weight-"
+	orderCountHeaderPrefix = "X-Mbx-Order-Count-"
+)
+
+var (
+	// defaultRateLimits are used until the limits are read from the exchange information
+	defaultRateLimits = []model.RateLimit{
+		{RateLimitType: model.RequestWeight, Interval: model.Minute, IntervalNum: 1, Limit: 1200},
+		{RateLimitType: model.OrdersLimit, Interval: model.Second, IntervalNum: 10, Limit: 50},
+		{RateLimitType: model.OrdersLimit, Interval: model.Day, IntervalNum: 1, Limit: 160000},
+	}
+
+	endpointWeights = make(map[string]weightFunc)
+	orderEndpoints  = make(map[string]int)
+	endpointMut     = sync.Mutex{}
+)
+
+// weightFunc calculates the weight of a call based on its parameters
+type weightFunc func(params Parameters) int
+
+func endpointKey(method, path string) string {
+	if n := strings.Index(path, "?"); n > -1 {
+		path = path[:n]
+	}
+	return method + " " + path
+}
+
+func setWeight(method, path string, weight int) {
+	setWeightFunc(method, path, func(Parameters) int { return weight })
+}
+
+func setWeightFunc(method, path string, fn weightFunc) {
+	endpointMut.Lock()
+	defer endpointMut.Unlock()
+	endpointWeights[endpointKey(method, path)] = fn
+}
+
+// countsAsOrder marks endpoints that count towards the ORDERS rate limits with the amount
+// of orders a single call places
+func countsAsOrder(method string, orders int, paths ...string) {
+	endpointMut.Lock()
+	defer endpointMut.Unlock()
+	for _, p := range paths {
+		orderEndpoints[endpointKey(method, p)] = orders
+	}
+}
+
+// requestWeight returns the weight of the endpoint, unknown endpoints weigh 1
+func requestWeight(method, path string, params Parameters) int {
+	endpointMut.Lock()
+	fn, ok := endpointWeights[endpointKey(method, path)]
+	endpointMut.Unlock()
+	if !ok {
+		return 1
+	}
+	if params == nil {
+		params = NewParameters()
+	}
+	return fn(params)
+}
+
+func orderCount(method, path string) int {
+	endpointMut.Lock()
+	defer endpointMut.Unlock()
+	return orderEndpoints[endpointKey(method, path)]
+}
+
+// rateWindow keeps track of the usage of a single rate limit
+type rateWindow struct {
+	limitType model.RateLimitType
+	interval  time.Duration
+	limit     int
+	used      int
+	start     time.Time
+}
+
+func (w *rateWindow) current(now time.Time) int {
+	if !now.Truncate(w.interval).Equal(w.start) {
+		return 0
+	}
+	return w.used
+}
+
+func (w *rateWindow) set(now time.Time, used int) {
+	w.start = now.Truncate(w.interval)
+	w.used = used
+}
+
+func (w *rateWindow) resetsIn(now time.Time) time.Duration {
+	return now.Truncate(w.interval).Add(w.interval).Sub(now)
+}
+
+func (w *rateWindow) cost(weight, orders int) int {
+	switch w.limitType {
+	case model.RequestWeight:
+		return weight
+	case model.OrdersLimit:
+		return orders
+	case model.RawRequests:
+		return 1
+	}
+	return 0
+}
+
+type weightChecker struct {
+	mut         sync.Mutex
+	bannedUntil time.Time
+	windows     []*rateWindow
+}
+
+func newWeightChecker() *weightChecker {
+	wc := &weightChecker{}
+	wc.setLimits(defaultRateLimits)
+	return wc
+}
+
+func (wc *weightChecker) window(limitType model.RateLimitType, interval time.Duration) *rateWindow {
+	for _, w := range wc.windows {
+		if w.limitType == limitType && w.interval == interval {
+			return w
+		}
+	}
+	w := &rateWindow{
+		limitType: limitType,
+		interval:  interval,
+	}
+	wc.windows = append(wc.windows, w)
+	return w
+}
+
+// setLimits updates the limits of the windows, usage that is already registered is kept
+func (wc *weightChecker) setLimits(limits []model.RateLimit) {
+	wc.mut.Lock()
+	defer wc.mut.Unlock()
+	for _, l := range limits {
+		if l.Duration() <= 0 {
+			continue
+		}
+		wc.window(l.RateLimitType, l.Duration()).limit = l.Limit
+	}
+}
+
+// reserve registers the weight and order count of a call before it is made. When the call
+// would exceed one of the limits it either waits for the window to reset or returns LimitExceeded.
+func (wc *weightChecker) reserve(ctx context.Context, weight, orders int, block bool) error {
+	for {
+		wc.mut.Lock()
+		now := time.Now()
+		var delay time.Duration
+		for _, w := range wc.windows {
+			n := w.cost(weight, orders)
+			if n == 0 || w.limit == 0 {
+				continue
+			}
+			used := w.current(now)
+			// a single call heavier than the limit can still be made on an empty window
+			if used > 0 && used+n > w.limit {
+				if d := w.resetsIn(now); d > delay {
+					delay = d
+				}
+			}
+		}
+		if delay == 0 {
+			for _, w := range wc.windows {
+				if n := w.cost(weight, orders); n > 0 {
+					w.set(now, w.current(now)+n)
+				}
+			}
+			wc.mut.Unlock()
+			return nil
+		}
+		wc.mut.Unlock()
+
+		if !block {
+			return LimitExceeded
+		}
+		if err := wait(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// update the usage with the values Binance reports in the response headers
+func (wc *weightChecker) update(header http.Header) {
+	wc.mut.Lock()
+	defer wc.mut.Unlock()
+	now := time.Now()
+	for k, v := range header {
+		var limitType model.RateLimitType
+		switch {
+		case strings.HasPrefix(k, usedWeightHeaderPrefix):
+			limitType = model.RequestWeight
+		case strings.HasPrefix(k, orderCountHeaderPrefix):
+			limitType = model.OrdersLimit
+		default:
+			continue
+		}
+		interval, ok := parseHeaderInterval(k[strings.LastIndex(k, "-")+1:])
+		if !ok || len(v) == 0 {
+			continue
+		}
+		used, err := strconv.Atoi(v[0])
+		if err != nil {
+			continue
+		}
+		wc.window(limitType, interval).set(now, used)
+	}
+}
+
+// parseHeaderInterval parses interval notations like 1m or 10s used in the rate limit headers
+func parseHeaderInterval(s string) (time.Duration, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	var d time.Duration
+	switch strings.ToLower(s[len(s)-1:]) {
+	case "s":
+		d = time.Second
+	case "m":
+		d = time.Minute
+	case "h":
+		d = time.Hour
+	case "d":
+		d = 24 * time.Hour
+	default:
+		return 0, false
+	}
+	return d * time.Duration(n), true
+}
+
+// usage returns the used amount and limit of the shortest window of the limit type
+func (wc *weightChecker) usage(limitType model.RateLimitType) (used, limit int) {
+	wc.mut.Lock()
+	defer wc.mut.Unlock()
+
+	var shortest *rateWindow
+	for _, w := range wc.windows {
+		if w.limitType == limitType && (shortest == nil || w.interval < shortest.interval) {
+			shortest = w
+		}
+	}
+	if shortest == nil {
+		return 0, 0
+	}
+	return shortest.current(time.Now()), shortest.limit
+}
+
+// allowed reports whether calls can be made or a ban by the API is still active
+func (wc *weightChecker) allowed() bool {
+	wc.mut.Lock()
+	defer wc.mut.Unlock()
+	return !time.Now().Before(wc.bannedUntil)
+}
+
+// banned returns the time until which the API does not accept calls, the zero
+// time is returned when no ban was issued
+func (wc *weightChecker) banned() time.Time {
+	wc.mut.Lock()
+	defer wc.mut.Unlock()
+	return wc.bannedUntil
+}
+
+func (wc *weightChecker) deactivate(until time.Time) {
+	wc.mut.Lock()
+	defer wc.mut.Unlock()
+	if until.After(wc.bannedUntil) {
+		wc.bannedUntil = until
+	}
+}
+
+func (wc *weightChecker) checkResponse(response *http.Response) error {
+	fn := func(response *http.Response, apiErr APIError) error {
+		retry := response.Header.Get("Retry-After")
+		if retry == "" {
+			return apiErr
+		}
+		until, err := parseRetryAfter(retry, time.Now())
+		if err != nil {
+			return fmt.Errorf("%w: %s", apiErr, err)
+		}
+		wc.deactivate(until)
+		return apiErr
+	}
+	if response.StatusCode == http.StatusTooManyRequests {
+		return fn(response, TooMuchCalls)
+	}
+	if response.StatusCode == http.StatusTeapot {
+		return fn(response, Blocked)
+	}
+	return nil
+}
+
+// parseRetryAfter reads the Retry-After header which holds either an amount of seconds or a HTTP date
+func parseRetryAfter(retry string, now time.Time) (time.Time, error) {
+	if i, err := strconv.Atoi(retry); err == nil {
+		if i < 0 {
+			return now, fmt.Errorf("negative Retry-After header %q", retry)
+		}
+		return now.Add(time.Duration(i) * time.Second), nil
+	}
+	t, err := http.ParseTime(retry)
+	if err != nil {
+		return now, fmt.Errorf("invalid Retry-After header %q", retry)
+	}
+	return t, nil
+}